@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/term"
+)
+
+type Context struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	Stdin  io.Reader
+
+	color bool
+}
+
+type Command struct {
+	Name    string
+	Summary string
+	Usage   string
+	Run     func(ctx *Context, args []string) error
+}
+
+// errUsage - ошибка в аргументах, сообщение уже выведено.
+var errUsage = errors.New("некорректные аргументы")
+
+func commands() []*Command {
+	return []*Command{
+		statsCommand,
+		monthsCommand,
+		sessionsCommand,
+		goalCommand,
+		levelCommand,
+		heatmapCommand,
+		watchCommand,
+		tuiCommand,
+		serveCommand,
+		exportCommand,
+		reportCommand,
+		traceCommand,
+		configCommand,
+		initCommand,
+		doctorCommand,
+	}
+}
+
+func findCommand(name string) *Command {
+	for _, cmd := range commands() {
+		if cmd.Name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// Run выполняет команду и возвращает код завершения. Вызов без команды или
+// с флагом первым аргументом (rqmc --exp) выполняет команду stats.
+func Run(args []string, stdout, stderr io.Writer, stdin io.Reader) int {
+	i18n.SetLanguage(i18n.Detect())
+
+	ctx := &Context{Stdout: stdout, Stderr: stderr, Stdin: stdin}
+	ctx.color = term.ColorEnabled(term.ModeAuto, stdout)
+
+	cmd := statsCommand
+	if len(args) > 0 {
+		switch {
+		case args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help":
+			return runHelp(ctx, args[1:])
+		case !strings.HasPrefix(args[0], "-"):
+			cmd = findCommand(args[0])
+			if cmd == nil {
+				fmt.Fprintf(stderr, "%s\n\n", i18n.T("неизвестная команда %q", args[0]))
+				printHelp(ctx)
+				return 2
+			}
+			args = args[1:]
+		}
+	}
+
+	err := cmd.Run(ctx, args)
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	case errors.Is(err, errChecksFailed):
+		return 1
+	default:
+		fmt.Fprintln(stderr, i18n.T("ошибка: %v", err))
+		return 1
+	}
+}
+
+func runHelp(ctx *Context, args []string) int {
+	if len(args) == 0 {
+		printHelp(ctx)
+		return 0
+	}
+
+	cmd := findCommand(args[0])
+	if cmd == nil {
+		fmt.Fprintln(ctx.Stderr, i18n.T("неизвестная команда %q", args[0]))
+		return 2
+	}
+	cmd.Run(ctx, []string{"--help"})
+	return 0
+}
+
+func printHelp(ctx *Context) {
+	fmt.Fprintln(ctx.Stderr, i18n.T("RQ_MobCounter - статистика убийств монстров по логам Royal Quest"))
+	fmt.Fprintln(ctx.Stderr)
+	fmt.Fprintln(ctx.Stderr, i18n.T("Использование: rqmc <команда> [флаги]"))
+	fmt.Fprintln(ctx.Stderr)
+	fmt.Fprintln(ctx.Stderr, i18n.T("Команды:"))
+	for _, cmd := range commands() {
+		fmt.Fprintf(ctx.Stderr, "  %-10s %s\n", cmd.Name, i18n.T(cmd.Summary))
+	}
+	fmt.Fprintln(ctx.Stderr)
+	fmt.Fprintln(ctx.Stderr, i18n.T("Без команды выполняется stats: rqmc --exp равносильно rqmc stats --exp"))
+	fmt.Fprintln(ctx.Stderr, i18n.T("Справка по команде: rqmc help <команда> или rqmc <команда> --help"))
+}
+
+// newFlagSet создаёт набор флагов команды, который выводит справку
+// и ошибки в ctx.Stderr вместо завершения программы.
+func newFlagSet(ctx *Context, cmd *Command) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	fs.SetOutput(ctx.Stderr)
+	fs.Usage = func() {
+		fmt.Fprint(ctx.Stderr, i18n.T("%s\n\nИспользование: %s\n\nФлаги:\n", i18n.T(cmd.Summary), i18n.T(cmd.Usage)))
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
+
+func (ctx *Context) paint(color, text string) string {
+	return term.Paint(ctx.color, color, text)
+}
+
+// printf переводит format на текущий язык, поэтому сообщения в вызовах
+// printf не нужно оборачивать в i18n.T.
+func (ctx *Context) printf(format string, args ...any) {
+	fmt.Fprint(ctx.Stdout, i18n.T(format, args...))
+}
+
+func (ctx *Context) println(args ...any) {
+	fmt.Fprintln(ctx.Stdout, args...)
+}
@@ -0,0 +1,641 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/i18n"
+)
+
+const logContent = "<HTML><BODY><TABLE width=800>" +
+	"<TR style='color:#4A92D3' valign=top title='1/16 06:45:41'><TD colspan=2>Злая шкатулка погибает. Получено опыта: 2873.\n" +
+	"<TR style='color:#4A92D3' valign=top title='1/16 06:45:53'><TD colspan=2>Часы погибает. Получено опыта: 17530.\n" +
+	"<TR style='color:#4A92D3' valign=top title='1/16 06:51:17'><TD colspan=2>Часы погибает. Получено опыта: 17530.\n" +
+	"<TR style='color:#4A92D3' valign=top title='1/17 10:00:00'><TD colspan=2>Росинка погибает. Получено опыта: 3.\n" +
+	"</TABLE></BODY></HTML>"
+
+// Сообщения в тестах проверяются на русском, поэтому язык из окружения
+// разработчика не должен на них влиять.
+func TestMain(m *testing.M) {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES"} {
+		os.Unsetenv(env)
+	}
+	os.Setenv("LANG", "ru_RU.UTF-8")
+	os.Exit(m.Run())
+}
+
+// setup создаёт папку с логом за январь 2026 и конфиг, указывающий на неё.
+func setup(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	logs := filepath.Join(dir, "logs")
+	if err := os.Mkdir(logs, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(logs, "exp (2026.01).htm"), []byte(logContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := json.Marshal(map[string]any{"version": 2, "log_path": logs, "file_prefix": "exp"})
+	configPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return configPath
+}
+
+func run(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := Run(args, &stdout, &stderr, strings.NewReader(""))
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunDefaultsToStats(t *testing.T) {
+	configPath := setup(t)
+
+	// Старый вызов без команды должен работать так же, как stats
+	code, legacy, _ := run("--exp", "--month", "2026.01", "--color", "never", "--config", configPath)
+	if code != 0 {
+		t.Fatalf("Legacy invocation exited with %d", code)
+	}
+
+	_, explicit, _ := run("stats", "--exp", "--month", "2026.01", "--color", "never", "--config", configPath)
+	if legacy != explicit {
+		t.Errorf("Legacy output differs from stats:\n%s\nvs\n%s", legacy, explicit)
+	}
+
+	if !strings.Contains(legacy, "Часы") || !strings.Contains(legacy, "Всего опыта: 37 936") {
+		t.Errorf("Unexpected stats output:\n%s", legacy)
+	}
+}
+
+func TestRunSharedFormatFlag(t *testing.T) {
+	configPath := setup(t)
+
+	code, out, _ := run("stats", "--format", "json", "--month", "2026.01", "--config", configPath)
+	if code != 0 {
+		t.Fatalf("stats exited with %d", code)
+	}
+	var items []map[string]any
+	if err := json.Unmarshal([]byte(out), &items); err != nil || len(items) != 3 {
+		t.Errorf("stats --format json: got %q (%v)", out, err)
+	}
+
+	code, out, _ = run("sessions", "--format", "json", "--month", "2026.01", "--config", configPath)
+	if code != 0 {
+		t.Fatalf("sessions exited with %d", code)
+	}
+	var sessions []sessionJSON
+	if err := json.Unmarshal([]byte(out), &sessions); err != nil || len(sessions) != 2 {
+		t.Fatalf("sessions --format json: got %q (%v)", out, err)
+	}
+	if sessions[0].Kills != 3 || sessions[0].Exp != 37933 {
+		t.Errorf("First session: got %+v", sessions[0])
+	}
+
+	// export не поддерживает table
+	if code, _, _ := run("export", "--format", "table", "--config", configPath); code != 1 {
+		t.Errorf("export --format table: got exit code %d, want 1", code)
+	}
+}
+
+func TestRunFormatDefault(t *testing.T) {
+	configPath := setup(t)
+	logs := filepath.Join(filepath.Dir(configPath), "logs")
+	data, _ := json.Marshal(map[string]any{"version": 2, "log_path": logs, "defaults": map[string]any{"format": "csv"}})
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if code, out, _ := run("stats", "--month", "2026.01", "--config", configPath); code != 0 || !strings.HasPrefix(out, "name,count") {
+		t.Errorf("stats with defaults.format=csv: got %d, %q", code, out)
+	}
+	// У months и sessions нет csv: используется их формат по умолчанию
+	for _, args := range [][]string{{"months"}, {"sessions", "--all"}} {
+		if code, _, stderr := run(append(args, "--color", "never", "--config", configPath)...); code != 0 {
+			t.Errorf("%v with defaults.format=csv: got %d, %q", args, code, stderr)
+		}
+	}
+}
+
+func TestRunExportToFile(t *testing.T) {
+	configPath := setup(t)
+	output := filepath.Join(t.TempDir(), "stats.csv")
+
+	code, _, _ := run("export", "--all", "--output", output, "--color", "never", "--config", configPath)
+	if code != 0 {
+		t.Fatalf("export exited with %d", code)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "name,count,exp,avg\nЧасы,2,35060,17530\nЗлая шкатулка,1,2873,2873\nРосинка,1,3,3\n"
+	if string(data) != want {
+		t.Errorf("export: got %q, want %q", data, want)
+	}
+}
+
+func TestRunHelpAndErrors(t *testing.T) {
+	tests := []struct {
+		args   []string
+		code   int
+		stderr string
+	}{
+		{[]string{"help"}, 0, "sessions"},
+		{[]string{"--help"}, 0, "Команды:"},
+		{[]string{"help", "months"}, 0, "rqmc months"},
+		{[]string{"stats", "-h"}, 0, "-exp"},
+		{[]string{"unknown"}, 2, `неизвестная команда "unknown"`},
+		{[]string{"stats", "--no-such-flag"}, 2, "no-such-flag"},
+		{[]string{"trace"}, 2, "укажите монстра"},
+		{[]string{"config"}, 2, "rqmc config path|show"},
+	}
+
+	for _, tt := range tests {
+		code, _, stderr := run(tt.args...)
+		if code != tt.code {
+			t.Errorf("%v: got exit code %d, want %d", tt.args, code, tt.code)
+		}
+		if !strings.Contains(stderr, tt.stderr) {
+			t.Errorf("%v: stderr %q should contain %q", tt.args, stderr, tt.stderr)
+		}
+	}
+}
+
+func TestRunCommandError(t *testing.T) {
+	configPath := setup(t)
+
+	code, _, stderr := run("stats", "--sort", "random", "--config", configPath)
+	if code != 1 {
+		t.Errorf("Invalid --sort: got exit code %d, want 1", code)
+	}
+	if !strings.Contains(stderr, "ошибка: некорректное значение --sort=random") {
+		t.Errorf("Invalid --sort: got stderr %q", stderr)
+	}
+}
+
+func TestRunConfigMigrate(t *testing.T) {
+	configPath := setup(t)
+	logs := filepath.Join(filepath.Dir(configPath), "logs")
+	old, _ := json.Marshal(map[string]any{"log_path": logs, "dedupe": true})
+	if err := os.WriteFile(configPath, old, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Обычные команды читают старый конфиг, но не переписывают его
+	for _, args := range [][]string{{"config", "path"}, {"doctor"}, {"stats", "--month", "2026.01"}} {
+		run(append(args, "--config", configPath)...)
+		if data, _ := os.ReadFile(configPath); string(data) != string(old) {
+			t.Errorf("%v rewrote the config: %s", args, data)
+		}
+	}
+
+	code, out, _ := run("config", "migrate", "--config", configPath)
+	if code != 0 || !strings.Contains(out, "обновлён до версии 2") {
+		t.Errorf("config migrate: got %d, %q", code, out)
+	}
+	data, _ := os.ReadFile(configPath)
+	if !strings.Contains(string(data), `"version": 2`) || !strings.Contains(string(data), `"dedupe": true`) {
+		t.Errorf("config migrate: got file %s", data)
+	}
+
+	if code, out, _ := run("config", "migrate", "--config", configPath); code != 0 || !strings.Contains(out, "уже версии 2") {
+		t.Errorf("config migrate again: got %d, %q", code, out)
+	}
+}
+
+func TestRunInit(t *testing.T) {
+	configPath := setup(t)
+	logs := filepath.Join(filepath.Dir(configPath), "logs")
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "xdg"))
+	t.Setenv("HOME", filepath.Join(dir, "home"))
+	t.Setenv("APPDATA", filepath.Join(dir, "appdata"))
+	t.Setenv("RQMC_CONFIG", "")
+
+	// Без найденного конфига он создаётся в папке настроек пользователя,
+	// а не рядом с приложением
+	if code, out, stderr := run("init", "--yes", "--log-path", logs, "--prefix", "exp"); code != 0 {
+		t.Fatalf("init: got %d, %q %q", code, out, stderr)
+	}
+	userPath, _ := config.UserPath()
+	if _, err := os.Stat(userPath); err != nil {
+		t.Errorf("init should create %s: %v", userPath, err)
+	}
+
+	if code, _, _ := run("init", "--yes", "--here", "--log-path", logs, "--prefix", "exp"); code != 0 {
+		t.Fatalf("init --here: got %d", code)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "config.json")); err != nil {
+		t.Errorf("init --here should create ./config.json: %v", err)
+	}
+
+	// Переменные окружения не попадают в сохранённый конфиг
+	t.Setenv("RQMC_DEDUPE", "true")
+	t.Setenv("RQMC_FILE_PREFIX", "chat")
+	if code, _, _ := run("init", "--yes", "--config", configPath, "--log-path", logs, "--prefix", "exp"); code != 0 {
+		t.Fatalf("init --config: got %d", code)
+	}
+	data, _ := os.ReadFile(configPath)
+	if strings.Contains(string(data), "dedupe") || strings.Contains(string(data), "chat") {
+		t.Errorf("init saved env overrides: %s", data)
+	}
+}
+
+func TestRunMonthsJSON(t *testing.T) {
+	configPath := setup(t)
+
+	code, out, _ := run("months", "--format", "json", "--config", configPath)
+	if code != 0 {
+		t.Fatalf("months exited with %d", code)
+	}
+
+	var months []monthJSON
+	if err := json.Unmarshal([]byte(out), &months); err != nil || len(months) != 1 {
+		t.Fatalf("months --format json: got %q (%v)", out, err)
+	}
+
+	m := months[0]
+	if m.Month != "2026.01" || m.Rows != 4 || m.Kills != 4 || m.Exp != 37936 || m.Size != int64(len(logContent)) {
+		t.Errorf("Month summary: got %+v", m)
+	}
+	if m.First != "2026-01-16T06:45:41" || m.Last != "2026-01-17T10:00:00" {
+		t.Errorf("Month range: got %s - %s", m.First, m.Last)
+	}
+	if len(m.GapDays) != 29 || m.GapDays[0] != "2026-01-01" || m.GapDays[15] != "2026-01-18" {
+		t.Errorf("Gap days: got %v", m.GapDays)
+	}
+}
+
+func TestRunColorModes(t *testing.T) {
+	configPath := setup(t)
+	t.Setenv("NO_COLOR", "")
+
+	tests := []struct {
+		color     string
+		wantColor bool
+	}{
+		// Вывод в буфер не является консолью, поэтому auto отключает цвет
+		{"auto", false},
+		{"never", false},
+		{"always", true},
+	}
+
+	for _, tt := range tests {
+		code, out, _ := run("stats", "--exp", "--month", "2026.01", "--color", tt.color, "--config", configPath)
+		if code != 0 {
+			t.Fatalf("--color=%s exited with %d", tt.color, code)
+		}
+		if got := strings.Contains(out, "\033["); got != tt.wantColor {
+			t.Errorf("--color=%s: colour in output = %v, want %v", tt.color, got, tt.wantColor)
+		}
+	}
+
+	_, out, _ := run("stats", "--exp", "--month", "2026.01", "--color", "always", "--theme", "none", "--config", configPath)
+	if !strings.HasPrefix(out, "Монстр") {
+		t.Errorf("--theme=none should not paint the table, got %q", out)
+	}
+}
+
+func TestRunStatsChart(t *testing.T) {
+	configPath := setup(t)
+	t.Setenv("COLUMNS", "")
+
+	code, out, _ := run("stats", "--month", "2026.01", "--chart", "count", "--sparkline", "--color", "never", "--config", configPath)
+	if code != 0 {
+		t.Fatalf("stats --chart: exited with %d", code)
+	}
+	// Часы - 2 убийства 16 января, остальные монстры - по одному 17-го
+	lines := strings.Split(out, "\n")
+	if !strings.HasSuffix(lines[2], "| "+strings.Repeat("█", 30)+" | █ ") {
+		t.Errorf("stats --chart: got %q", lines[2])
+	}
+
+	_, out, _ = run("stats", "--month", "2026.01", "--chart", "exp", "--ascii", "--color", "never", "--config", configPath)
+	if strings.ContainsAny(out, "█▏") || !strings.Contains(out, strings.Repeat("#", 30)) {
+		t.Errorf("stats --chart --ascii: got %q", out)
+	}
+
+	t.Setenv("COLUMNS", "90")
+	_, out, _ = run("stats", "--month", "2026.01", "--chart", "count", "--color", "never", "--config", configPath)
+	if line := strings.Split(out, "\n")[2]; len([]rune(line)) != 90 {
+		t.Errorf("stats --chart with COLUMNS=90: got %d characters, %q", len([]rune(line)), line)
+	}
+
+	if code, _, stderr := run("stats", "--chart", "avg", "--config", configPath); code != 1 || !strings.Contains(stderr, "--chart") {
+		t.Errorf("stats --chart avg: got %d, %q", code, stderr)
+	}
+}
+
+func TestRunLanguage(t *testing.T) {
+	configPath := setup(t)
+	t.Setenv("LANG", "en_US.UTF-8")
+	defer i18n.SetLanguage(i18n.Russian)
+
+	code, out, _ := run("stats", "--exp", "--month", "2026.01", "--config", configPath)
+	if code != 0 {
+		t.Fatalf("stats exited with %d", code)
+	}
+	if !strings.HasPrefix(out, "Monster") || !strings.Contains(out, "Total exp: 37,936") {
+		t.Errorf("LANG=en_US.UTF-8 should switch to english, got:\n%s", out)
+	}
+
+	// language в конфиге важнее переменных окружения
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = bytes.Replace(data, []byte("{"), []byte(`{"language": "ru",`), 1)
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, out, _ = run("stats", "--exp", "--month", "2026.01", "--config", configPath)
+	if !strings.Contains(out, "Всего опыта: 37 936") {
+		t.Errorf("language in config should override LANG, got:\n%s", out)
+	}
+}
+
+func TestRunGoal(t *testing.T) {
+	configPath := setup(t)
+
+	// defaults.show_exp относится к stats и не должен превращаться в цель по опыту
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = bytes.Replace(data, []byte("{"), []byte(`{"defaults": {"show_exp": true},`), 1)
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, out, stderr := run("goal", "add", "--kills", "4", "--monster", "часы", "--since", "2026-01-16", "--config", configPath)
+	if code != 0 || !strings.Contains(out, "Добавлена цель #1 часы: убийств 4 (с 2026-01-16 00:00)") {
+		t.Fatalf("goal add: got %d, %q, %q", code, out, stderr)
+	}
+	if code, _, stderr := run("goal", "add", "--kills", "4", "--exp", "10M", "--config", configPath); code != 1 || !strings.Contains(stderr, "укажите одно из --kills или --exp") {
+		t.Errorf("goal add with both targets: got %d, %q", code, stderr)
+	}
+	if code, _, stderr := run("goal", "add", "--exp", "2.5k", "--since", "2026-01-17", "--name", "Росинки", "--config", configPath); code != 0 {
+		t.Fatalf("goal add --exp: got %d, %q", code, stderr)
+	}
+
+	code, out, _ = run("goal", "list", "--format", "json", "--config", configPath)
+	var items []goalJSON
+	if err := json.Unmarshal([]byte(out), &items); code != 0 || err != nil || len(items) != 2 {
+		t.Fatalf("goal list --format json: got %d, %q (%v)", code, out, err)
+	}
+	if p := items[0]; p.Current != 2 || p.Percent != 50 || p.RatePerHour != 22 || p.ETAMinutes != 5 || p.CompletedAt != "" {
+		t.Errorf("Kill goal: got %+v", p)
+	}
+	if p := items[1]; p.Metric != "exp" || p.Target != 2500 || p.Current != 3 || p.Name != "Росинки" {
+		t.Errorf("Exp goal: got %+v", p)
+	}
+
+	_, out, _ = run("goal", "list", "--color", "never", "--config", configPath)
+	if !strings.Contains(out, "[██████████░░░░░░░░░░]  50%  2 / 4") {
+		t.Errorf("goal list: got %q", out)
+	}
+
+	if code, out, _ := run("goal", "rm", "1", "--config", configPath); code != 0 || !strings.Contains(out, "Цель #1 удалена") {
+		t.Errorf("goal rm: got %d, %q", code, out)
+	}
+	if code, _, stderr := run("goal", "rm", "1", "--config", configPath); code != 1 || !strings.Contains(stderr, "цель #1 не найдена") {
+		t.Errorf("goal rm missing: got %d, %q", code, stderr)
+	}
+	if code, _, _ := run("goal", "--config", configPath); code != 2 {
+		t.Errorf("goal without action: got exit code %d, want 2", code)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 1, 16, 10, 30, 15, 0, time.Local) // пятница
+
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"", now},
+		{"today", time.Date(2026, 1, 16, 0, 0, 0, 0, time.Local)},
+		{"week", time.Date(2026, 1, 12, 0, 0, 0, 0, time.Local)},
+		{"month", time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)},
+		{"2026-01-10 18:00", time.Date(2026, 1, 10, 18, 0, 0, 0, time.Local)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSince(tt.value, now)
+		if err != nil || !got.Equal(tt.expected) {
+			t.Errorf("parseSince(%q): got %s (%v), want %s", tt.value, got, err, tt.expected)
+		}
+	}
+	if _, err := parseSince("yesterday", now); err == nil {
+		t.Errorf("parseSince(yesterday): expected error")
+	}
+}
+
+func TestRunLevel(t *testing.T) {
+	configPath := setup(t)
+	dir := filepath.Dir(configPath)
+
+	code, out, _ := run("level", "--month", "2026.01", "--config", configPath)
+	if code != 0 || !strings.Contains(out, "нет сообщений «Вы достигли N уровня!»") {
+		t.Errorf("level without level-ups: got %d, %q", code, out)
+	}
+
+	content := strings.Replace(logContent, "</TABLE>",
+		"<TR style='color:#4A92D3' valign=top title='1/16 06:45:53'><TD colspan=2>Вы достигли 42 уровня!\n</TABLE>", 1)
+	if err := os.WriteFile(filepath.Join(dir, "logs", "exp (2026.01).htm"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	code, out, _ = run("level", "--month", "2026.01", "--color", "never", "--config", configPath)
+	if code != 0 || !strings.Contains(out, "Опыт для 42 уровня неизвестен") {
+		t.Errorf("level without levels.csv: got %d, %q", code, out)
+	}
+
+	// Встроенная таблица пустая, опыт для уровней берётся из levels.csv
+	if err := os.WriteFile(filepath.Join(dir, "levels.csv"), []byte("42,70000\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, out, _ = run("level", "--month", "2026.01", "--color", "never", "--config", configPath)
+	if code != 0 || !strings.Contains(out, "Уровень 42: 17 533 / 70 000 опыта (25%), осталось 52 467") {
+		t.Errorf("level: got %d, %q", code, out)
+	}
+
+	code, out, _ = run("level", "--month", "2026.01", "--format", "json", "--config", configPath)
+	var report levelReportJSON
+	if err := json.Unmarshal([]byte(out), &report); code != 0 || err != nil {
+		t.Fatalf("level --format json: got %d, %q (%v)", code, out, err)
+	}
+	if len(report.Levels) != 1 || report.Levels[0].Kills != 2 || report.Current == nil || report.Current.Need != 70000 {
+		t.Errorf("level --format json: got %+v", report)
+	}
+
+	if code, _, stderr := run("level", "--profile", "all", "--config", configPath); code != 1 || !strings.Contains(stderr, "укажите --profile имя") {
+		t.Errorf("level --profile all: got %d, %q", code, stderr)
+	}
+}
+
+func TestLevelTablePath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("APPDATA", dir)
+
+	// Без файла конфига levels.csv ищется там же, куда сохраняется конфиг
+	userPath, err := config.UserPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := filepath.Join(filepath.Dir(userPath), "levels.csv")
+	if got := levelTablePath(&config.Config{}); got != want {
+		t.Errorf("levelTablePath without config: got %q, want %q", got, want)
+	}
+}
+
+func TestRunHeatmapJSON(t *testing.T) {
+	configPath := setup(t)
+
+	code, out, _ := run("heatmap", "--month", "2026.01", "--format", "json", "--filter", "часы", "--config", configPath)
+	var h heatmapJSON
+	if err := json.Unmarshal([]byte(out), &h); code != 0 || err != nil {
+		t.Fatalf("heatmap --format json: got %d, %q (%v)", code, out, err)
+	}
+	// Часы убиты в пятницу 16 января в 6 утра
+	if h.Weekdays[4] != "fri" || h.Kills[4][6] != 2 || h.Exp[4][6] != 35060 || h.Kills[5][10] != 0 {
+		t.Errorf("heatmap --format json: got %+v", h)
+	}
+}
+
+func TestRunHeatmapExp(t *testing.T) {
+	configPath := setup(t)
+
+	// defaults.show_exp относится к stats и не переключает закраску
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = bytes.Replace(data, []byte("{"), []byte(`{"defaults": {"show_exp": true},`), 1)
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "максимум 3 убийств в"},
+		{[]string{"--exp"}, "максимум 37 933 опыта в"},
+	}
+	for _, tt := range tests {
+		args := append([]string{"heatmap", "--month", "2026.01", "--ascii", "--config", configPath}, tt.args...)
+		if code, out, _ := run(args...); code != 0 || !strings.Contains(out, tt.want) {
+			t.Errorf("heatmap %v: got %d, %q", tt.args, code, out)
+		}
+	}
+
+	output := filepath.Join(t.TempDir(), "calendar.svg")
+	if code, _, stderr := run("report", "--month", "2026.01", "--svg", output, "--exp", "--config", configPath); code != 0 {
+		t.Errorf("report --exp: got %d, %q", code, stderr)
+	}
+}
+
+func TestTUIBrowserTruncatedLog(t *testing.T) {
+	configPath := setup(t)
+	logPath := currentLog(t, configPath)
+	file, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(logRow(time.Now(), "Росинка", 3))
+	file.Close()
+
+	cfg, err := loadConfig(configPath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := newTUIBrowser(cfg, "count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.poll()
+	if got := len(b.cache[currentMonth()]); got != 2 {
+		t.Fatalf("Initial poll: got %d entries, want 2", got)
+	}
+
+	// Лог перезаписан заново: записи заменяются, а не добавляются
+	currentLog(t, configPath)
+	b.poll()
+	if got := b.cache[currentMonth()]; len(got) != 1 || got[0].MonsterName != "Часы" {
+		t.Errorf("Poll after truncation: got %+v", got)
+	}
+}
+
+func TestRunTUI(t *testing.T) {
+	configPath := setup(t)
+
+	// В тестах ввод - не консоль, поэтому полноэкранный режим недоступен
+	if code, _, stderr := run("tui", "--config", configPath); code != 1 || !strings.Contains(stderr, "rqmc tui работает только в консоли") {
+		t.Errorf("tui without console: got %d, %q", code, stderr)
+	}
+	if code, _, stderr := run("tui", "--sort", "size", "--config", configPath); code != 1 || !strings.Contains(stderr, "size") {
+		t.Errorf("tui --sort size: got %d, %q", code, stderr)
+	}
+	if code, _, stderr := run("tui", "--profile", "all", "--config", configPath); code != 1 || !strings.Contains(stderr, "укажите --profile имя") {
+		t.Errorf("tui --profile all: got %d, %q", code, stderr)
+	}
+}
+
+func TestRunReportSVG(t *testing.T) {
+	configPath := setup(t)
+	output := filepath.Join(t.TempDir(), "calendar.svg")
+
+	code, out, _ := run("report", "--month", "2026.01", "--svg", output, "--color", "never", "--config", configPath)
+	if code != 0 || !strings.Contains(out, "Календарь сохранён в") {
+		t.Fatalf("report --svg: got %d, %q", code, out)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "<svg") || !strings.Contains(string(data), "<title>2026-01-16: убийств 3, опыт 37 933</title>") {
+		t.Errorf("report --svg: got %s", data)
+	}
+
+	if code, _, stderr := run("report", "--config", configPath); code != 1 || !strings.Contains(stderr, "--svg") {
+		t.Errorf("report without output: got %d, %q", code, stderr)
+	}
+}
+
+func TestRunReportHTML(t *testing.T) {
+	configPath := setup(t)
+	output := filepath.Join(t.TempDir(), "report.html")
+
+	code, out, _ := run("report", "--month", "2026.01", "--html", output, "--color", "never", "--config", configPath)
+	if code != 0 || !strings.Contains(out, "Отчёт сохранён в") {
+		t.Fatalf("report --html: got %d, %q", code, out)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(data)
+	for _, want := range []string{"<!DOCTYPE html>", "<style>", "<svg", `<td>Часы</td><td class="num" data-value="2">2</td>`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("report --html: missing %q", want)
+		}
+	}
+	// Страница открывается без сети
+	if strings.Contains(page, " src=") || strings.Contains(page, "https://") {
+		t.Errorf("report --html: external resources in %s", page)
+	}
+}
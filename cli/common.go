@@ -0,0 +1,347 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/dedupe"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/logfiles"
+	"RQ_MobCounter/normalize"
+	"RQ_MobCounter/parser"
+	"RQ_MobCounter/term"
+)
+
+// commonFlags - флаги, общие для всех команд, работающих с логами.
+type commonFlags struct {
+	config  string
+	profile string
+	format  string
+	color   string
+
+	formats []string
+}
+
+type rangeFlags struct {
+	month  string
+	all    bool
+	dedupe bool
+}
+
+type selection struct {
+	entries    []parser.LogEntry
+	levels     []parser.LevelUp
+	files      int
+	duplicates int
+	characters []string
+}
+
+type monthNotFoundError struct {
+	month   string
+	current bool
+}
+
+func (e *monthNotFoundError) Error() string {
+	return i18n.T("файл для месяца %s не найден", e.month)
+}
+
+type noFilesError struct{}
+
+func (noFilesError) Error() string {
+	return i18n.T("нет файлов для обработки")
+}
+
+var errNoFiles error = noFilesError{}
+
+// addCommonFlags регистрирует --config, --profile, --color и, если formats
+// не пуст, --format с первым значением по умолчанию.
+func addCommonFlags(fs *flag.FlagSet, formats ...string) *commonFlags {
+	c := &commonFlags{formats: formats}
+	fs.StringVar(&c.config, "config", "", i18n.T("путь к файлу конфига"))
+	fs.StringVar(&c.profile, "profile", "", i18n.T("профиль персонажа из конфига или all для всех профилей"))
+	fs.StringVar(&c.color, "color", term.ModeAuto, i18n.T("цветной вывод: auto (только в консоли и без NO_COLOR), always или never"))
+	if len(formats) > 0 {
+		fs.StringVar(&c.format, "format", formats[0], i18n.T("формат вывода: %s", strings.Join(formats, ", ")))
+	}
+	return c
+}
+
+func addRangeFlags(fs *flag.FlagSet) *rangeFlags {
+	r := &rangeFlags{}
+	fs.StringVar(&r.month, "month", "", i18n.T("анализ конкретного месяца (YYYY.MM)"))
+	fs.BoolVar(&r.all, "all", false, i18n.T("обработка всех файлов"))
+	fs.BoolVar(&r.dedupe, "dedupe", false, i18n.T("удалять повторяющиеся записи из перекрывающихся логов"))
+	return r
+}
+
+// setup загружает конфиг выбранного профиля, подставляет значения флагов
+// по умолчанию и проверяет общие флаги.
+func (ctx *Context) setup(fs *flag.FlagSet, common *commonFlags) (*config.Config, error) {
+	cfg, err := loadConfig(common.config, common.profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.applyFlags(fs, common, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// applyFlags подставляет значения флагов по умолчанию из уже загруженного
+// конфига и проверяет общие флаги.
+func (ctx *Context) applyFlags(fs *flag.FlagSet, common *commonFlags, cfg *config.Config) error {
+	// defaults.format общий для всех команд: формат, которого у команды нет
+	// (например, csv у months), не должен ломать её вызов
+	defaults := cfg.Defaults
+	if !slices.Contains(common.formats, defaults.Format) {
+		defaults.Format = ""
+	}
+	if err := config.ApplyDefaults(fs, defaults); err != nil {
+		return err
+	}
+
+	if err := checkChoice("color", common.color, config.ColorValues); err != nil {
+		return err
+	}
+	ctx.color = term.ColorEnabled(common.color, ctx.Stdout)
+
+	if len(common.formats) > 0 {
+		return checkChoice("format", common.format, common.formats)
+	}
+	return nil
+}
+
+// loadConfig загружает конфиг и, если указан профиль (кроме all),
+// возвращает настройки этого профиля.
+func loadConfig(path, profile string) (*config.Config, error) {
+	cfg, err := config.LoadFrom(path)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка загрузки конфига: %w", err)
+	}
+	useLanguage(cfg)
+
+	if profile != "" && profile != config.AllProfiles {
+		return cfg.Profile(profile)
+	}
+
+	return cfg, nil
+}
+
+// useLanguage переключает язык сообщений, если он указан в конфиге.
+// Иначе остаётся язык из переменных окружения.
+func useLanguage(cfg *config.Config) {
+	if lang, ok := i18n.Parse(cfg.Language); ok {
+		i18n.SetLanguage(lang)
+	}
+}
+
+// loadSelection загружает записи выбранных месяцев. При --profile all
+// записи всех профилей объединяются, а у каждой записи указывается
+// персонаж. Возвращает nil без ошибки, если обрабатывать нечего и
+// сообщение пользователю уже выведено.
+func (ctx *Context) loadSelection(cfg *config.Config, profile string, r *rangeFlags) (*selection, error) {
+	sel, err := readSelection(cfg, profile, r)
+
+	var notFound *monthNotFoundError
+	if errors.As(err, &notFound) && notFound.current {
+		ctx.printf("файл для текущего месяца %s не найден. Доступные файлы:\n", notFound.month)
+		ctx.listAvailableFiles(cfg.LogPath, cfg.FilePrefix)
+		return nil, nil
+	}
+	if errors.Is(err, errNoFiles) {
+		ctx.println(err)
+		return nil, nil
+	}
+
+	return sel, err
+}
+
+// readSelection делает то же, что loadSelection, но ничего не выводит:
+// отсутствие файлов возвращается как monthNotFoundError или errNoFiles.
+func readSelection(cfg *config.Config, profile string, r *rangeFlags) (*selection, error) {
+	if profile != config.AllProfiles {
+		if err := cfg.Validate(); err != nil {
+			return nil, err
+		}
+
+		files, err := findFiles(cfg, r.month, r.all)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, errNoFiles
+		}
+
+		doc := loadDocuments(files)
+		sel := &selection{entries: doc.Entries, levels: doc.Levels, files: len(files)}
+		if r.dedupe {
+			sel.entries, sel.duplicates = dedupe.Remove(sel.entries, dedupe.DefaultMinRun)
+		}
+		return sel, nil
+	}
+
+	names := cfg.ProfileNames()
+	if len(names) == 0 {
+		return nil, errors.New(i18n.T("в конфиге нет секции profiles"))
+	}
+
+	sel := &selection{}
+	for _, name := range names {
+		profileCfg, err := cfg.Profile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := profileCfg.Validate(); err != nil {
+			log.Print(i18n.T("профиль %s пропущен: %v", name, err))
+			continue
+		}
+
+		files, err := findFiles(profileCfg, r.month, r.all)
+		if err != nil {
+			log.Print(i18n.T("профиль %s пропущен: %v", name, err))
+			continue
+		}
+
+		entries := loadEntries(files)
+		if r.dedupe {
+			var duplicates int
+			entries, duplicates = dedupe.Remove(entries, dedupe.DefaultMinRun)
+			sel.duplicates += duplicates
+		}
+
+		for i := range entries {
+			entries[i].Character = name
+		}
+
+		sel.entries = append(sel.entries, entries...)
+		sel.files += len(files)
+		sel.characters = append(sel.characters, name)
+	}
+
+	if len(sel.characters) == 0 {
+		return nil, errNoFiles
+	}
+
+	return sel, nil
+}
+
+func findFiles(cfg *config.Config, month string, all bool) ([]logfiles.File, error) {
+	files, err := discoverFiles(cfg)
+	if err != nil || all {
+		return files, err
+	}
+
+	current := month == ""
+	if current {
+		month = currentMonth()
+	}
+
+	for _, file := range files {
+		if file.Month == month {
+			return []logfiles.File{file}, nil
+		}
+	}
+	return nil, &monthNotFoundError{month: month, current: current}
+}
+
+// discoverFiles ищет файлы логов профиля и предупреждает об архивах,
+// которые не удалось открыть: остальные месяцы при этом доступны.
+func discoverFiles(cfg *config.Config) ([]logfiles.File, error) {
+	files, skipped, err := logfiles.Scan(cfg.LogPath, cfg.FilePrefix)
+	for _, err := range skipped {
+		log.Print(i18n.T("архив пропущен: %v", err))
+	}
+	return files, err
+}
+
+func loadEntries(files []logfiles.File) []parser.LogEntry {
+	return loadDocuments(files).Entries
+}
+
+// loadDocuments объединяет записи и повышения уровня из всех файлов.
+func loadDocuments(files []logfiles.File) parser.Document {
+	var all parser.Document
+
+	for _, file := range files {
+		doc, err := logfiles.LoadDocument(file)
+		if err != nil {
+			log.Print(i18n.T("ошибка при парсинге %s: %v", file.Name(), err))
+			continue
+		}
+
+		all.Entries = append(all.Entries, doc.Entries...)
+		all.Levels = append(all.Levels, doc.Levels...)
+		all.Rows += doc.Rows
+	}
+
+	return all
+}
+
+func (ctx *Context) listAvailableFiles(logPath, prefix string) {
+	files, err := logfiles.Discover(logPath, prefix)
+	if err != nil {
+		ctx.printf("%v\n", err)
+		return
+	}
+
+	for _, file := range files {
+		ctx.printf("  - %s  %s\n", file.Month, file.Name())
+	}
+	if len(files) > 0 {
+		ctx.println(i18n.T("Подробная сводка по месяцам: rqmc months"))
+	}
+}
+
+func newNormalizer(cfg *config.Config) *normalize.Normalizer {
+	return normalize.New(normalize.Options{
+		Prefixes: cfg.Normalize.Prefixes,
+		Suffixes: cfg.Normalize.Suffixes,
+		Aliases:  cfg.Normalize.Aliases,
+	})
+}
+
+// normalizeEntries возвращает копию записей с нормализованными именами
+// монстров.
+func normalizeEntries(entries []parser.LogEntry, n *normalize.Normalizer) []parser.LogEntry {
+	result := make([]parser.LogEntry, len(entries))
+	for i, e := range entries {
+		e.MonsterName = n.Name(e.MonsterName)
+		result[i] = e
+	}
+	return result
+}
+
+func currentMonth() string {
+	now := time.Now()
+	return fmt.Sprintf("%d.%02d", now.Year(), now.Month())
+}
+
+func checkChoice(name, value string, allowed []string) error {
+	if !slices.Contains(allowed, value) {
+		return i18n.Errorf("некорректное значение --%s=%s, допустимо: %s", name, value, strings.Join(allowed, ", "))
+	}
+	return nil
+}
+
+func parseColumns(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var columns []string
+	for _, column := range strings.Split(value, ",") {
+		column = strings.TrimSpace(column)
+		if err := checkChoice("columns", column, config.ColumnValues); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/term"
+)
+
+var configCommand = &Command{
+	Name:    "config",
+	Summary: i18n.N("какой файл конфига используется, итоговые настройки и обновление схемы"),
+	Usage:   i18n.N("rqmc config path|show|migrate [--config путь] [--profile имя]"),
+}
+
+func init() {
+	configCommand.Run = runConfig
+}
+
+func runConfig(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, configCommand)
+	configPath := fs.String("config", "", i18n.T("путь к файлу конфига"))
+	profile := fs.String("profile", "", i18n.T("показать настройки профиля (для show)"))
+
+	if len(args) == 0 || (args[0] != "path" && args[0] != "show" && args[0] != "migrate") {
+		if err := parseFlags(fs, args); err != nil {
+			return err
+		}
+		fs.Usage()
+		return errUsage
+	}
+
+	action := args[0]
+	if err := parseFlags(fs, args[1:]); err != nil {
+		return err
+	}
+
+	switch action {
+	case "show":
+		return showConfig(ctx, *configPath, *profile)
+	case "migrate":
+		return migrateConfig(ctx, *configPath)
+	}
+	return showConfigPath(ctx, *configPath)
+}
+
+func showConfigPath(ctx *Context, configPath string) error {
+	cfg, err := config.LoadFrom(configPath)
+	if err != nil {
+		return i18n.Errorf("ошибка загрузки конфига: %w", err)
+	}
+	useLanguage(cfg)
+
+	if cfg.Path() != "" {
+		ctx.println(ctx.paint(term.Green, i18n.T("Используется: %s", cfg.Path())))
+	} else {
+		ctx.println(ctx.paint(term.Yellow, i18n.T("Файл конфига не найден, используются значения по умолчанию")))
+	}
+
+	ctx.println(i18n.T("\nПорядок поиска:"))
+	marked := false
+	for i, c := range config.Candidates(configPath) {
+		mark := " "
+		if !marked && c.Path == cfg.Path() {
+			mark = "*"
+			marked = true
+		}
+		ctx.printf("%s %d. %s (%s)\n", mark, i+1, c.Path, c.Source)
+	}
+
+	header := false
+	for _, env := range []string{config.EnvLogPath, config.EnvFilePrefix, config.EnvDedupe} {
+		if v := os.Getenv(env); v != "" {
+			if !header {
+				ctx.println(i18n.T("\nПереопределено переменными окружения:"))
+				header = true
+			}
+			ctx.printf("  %s=%s\n", env, v)
+		}
+	}
+	return nil
+}
+
+// showConfig выводит итоговые настройки с учётом переменных окружения,
+// миграций и выбранного профиля.
+func showConfig(ctx *Context, configPath, profile string) error {
+	cfg, err := loadConfig(configPath, profile)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return i18n.Errorf("ошибка формирования JSON: %w", err)
+	}
+	ctx.printf("%s\n", data)
+	return nil
+}
+
+// migrateConfig переписывает файл конфига старой версии в текущей схеме.
+// Остальные команды переводят конфиг в новую версию только в памяти.
+func migrateConfig(ctx *Context, configPath string) error {
+	path, err := config.Locate(configPath)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return errors.New(i18n.T("файл конфига не найден, создайте его командой rqmc init"))
+	}
+
+	// Читаем файл без переменных окружения, чтобы не записать их в него
+	cfg, err := config.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	useLanguage(cfg)
+
+	if !cfg.Migrated() {
+		ctx.println(i18n.T("Конфиг %s уже версии %d", path, config.CurrentVersion))
+		return nil
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+	ctx.println(ctx.paint(term.Green, i18n.T("Конфиг %s обновлён до версии %d", path, config.CurrentVersion)))
+	return nil
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"errors"
+	"time"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/doctor"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/term"
+)
+
+var doctorCommand = &Command{
+	Name:    "doctor",
+	Summary: i18n.N("проверка конфига и логов с подсказками по исправлению"),
+	Usage:   i18n.N("rqmc doctor [--config путь]"),
+}
+
+func init() {
+	doctorCommand.Run = runDoctor
+}
+
+// errChecksFailed - хотя бы одна проверка не пройдена, результаты уже выведены.
+var errChecksFailed = errors.New("есть непройденные проверки")
+
+func runDoctor(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, doctorCommand)
+	configPath := fs.String("config", "", i18n.T("путь к файлу конфига"))
+	colorMode := fs.String("color", term.ModeAuto, i18n.T("цветной вывод: auto, always или never"))
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := checkChoice("color", *colorMode, config.ColorValues); err != nil {
+		return err
+	}
+	ctx.color = term.ColorEnabled(*colorMode, ctx.Stdout)
+
+	failed := false
+	for _, r := range doctor.Run(*configPath, time.Now()) {
+		var mark string
+		switch r.Status {
+		case doctor.Pass:
+			mark = ctx.paint(term.Green, "[ OK ]")
+		case doctor.Warn:
+			mark = ctx.paint(term.Yellow, "[ !! ]")
+		default:
+			mark = ctx.paint(term.Red, "[FAIL]")
+			failed = true
+		}
+
+		ctx.printf("%s %s: %s\n", mark, r.Name, r.Message)
+		if r.Status != doctor.Pass && r.Fix != "" {
+			ctx.printf("       → %s\n", r.Fix)
+		}
+	}
+
+	if failed {
+		return errChecksFailed
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"os"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/stats"
+	"RQ_MobCounter/term"
+)
+
+var exportCommand = &Command{
+	Name:    "export",
+	Summary: i18n.N("выгрузка полной статистики в CSV или JSON"),
+	Usage:   i18n.N("rqmc export [--format csv|json] [--output файл] [--month YYYY.MM | --all] [флаги]"),
+}
+
+func init() {
+	exportCommand.Run = runExport
+}
+
+func runExport(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, exportCommand)
+	common := addCommonFlags(fs, "csv", "json")
+	rng := addRangeFlags(fs)
+	output := fs.String("output", "", i18n.T("файл для сохранения (по умолчанию вывод в консоль)"))
+	sortBy := fs.String("sort", "count", i18n.T("сортировка: count, exp, avg или name"))
+	columns := fs.String("columns", "", i18n.T("колонки CSV через запятую: name, count, exp, avg"))
+	filter := fs.String("filter", "", i18n.T("только монстры, в имени которых есть подстрока"))
+	minCount := fs.Int("min-count", 0, i18n.T("только монстры, убитые не меньше N раз"))
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.setup(fs, common)
+	if err != nil {
+		return err
+	}
+
+	if err := checkChoice("sort", *sortBy, config.SortValues); err != nil {
+		return err
+	}
+	csvColumns, err := parseColumns(*columns)
+	if err != nil {
+		return err
+	}
+
+	sel, err := ctx.loadSelection(cfg, common.profile, rng)
+	if err != nil || sel == nil {
+		return err
+	}
+
+	calculator := stats.NewCalculator(sel.entries)
+	calculator.SetNormalizer(newNormalizer(cfg).Name)
+	calculator.SetFilter(*filter)
+	calculator.SetMinCount(*minCount)
+	monsterStats := calculator.Calculate(*sortBy, 0)
+
+	var data string
+	if common.format == "json" {
+		data, err = stats.FormatJSON(monsterStats)
+	} else {
+		data, err = stats.FormatCSV(monsterStats, csvColumns)
+	}
+	if err != nil {
+		return i18n.Errorf("ошибка формирования %s: %w", common.format, err)
+	}
+
+	if *output == "" {
+		ctx.printf("%s", data)
+		return nil
+	}
+
+	if err := os.WriteFile(*output, []byte(data), 0644); err != nil {
+		return i18n.Errorf("ошибка записи %s: %w", *output, err)
+	}
+	ctx.println(ctx.paint(term.Green, i18n.T("Сохранено монстров: %d в %s", len(monsterStats), *output)))
+	return nil
+}
@@ -0,0 +1,388 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/dedupe"
+	"RQ_MobCounter/goals"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/logfiles"
+	"RQ_MobCounter/normalize"
+	"RQ_MobCounter/notify"
+	"RQ_MobCounter/parser"
+	"RQ_MobCounter/stats"
+	"RQ_MobCounter/term"
+)
+
+var goalCommand = &Command{
+	Name:    "goal",
+	Summary: i18n.N("цели по убийствам и опыту: добавление, прогресс и удаление"),
+	Usage:   i18n.N("rqmc goal add|list|rm [--kills N | --exp N] [--monster имя] [--since дата|today|week|month] [--name текст] [номер]"),
+}
+
+func init() {
+	goalCommand.Run = runGoal
+}
+
+const goalBarWidth = 20
+
+type goalJSON struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Profile     string `json:"profile,omitempty"`
+	Monster     string `json:"monster,omitempty"`
+	Metric      string `json:"metric"`
+	Target      int    `json:"target"`
+	Start       string `json:"start"`
+	Current     int    `json:"current"`
+	Percent     int    `json:"percent"`
+	CompletedAt string `json:"completed_at,omitempty"`
+	RatePerHour int    `json:"rate_per_hour"`
+	ETAMinutes  int    `json:"eta_minutes,omitempty"`
+}
+
+func runGoal(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, goalCommand)
+	common := addCommonFlags(fs, "table", "json")
+	kills := fs.String("kills", "", i18n.T("цель по количеству убийств (для add)"))
+	exp := fs.String("exp", "", i18n.T("цель по опыту, можно с суффиксом k или M: 10M (для add)"))
+	monster := fs.String("monster", "", i18n.T("часть имени монстра, без него считаются все (для add)"))
+	since := fs.String("since", "", i18n.T("начало отсчёта: YYYY-MM-DD [HH:MM], today, week или month; по умолчанию сейчас (для add)"))
+	name := fs.String("name", "", i18n.T("название цели (для add)"))
+	gap := fs.Duration("gap", stats.DefaultSessionGap, i18n.T("перерыв, после которого начинается новая сессия"))
+	dedupeFlag := fs.Bool("dedupe", false, i18n.T("удалять повторяющиеся записи из перекрывающихся логов"))
+
+	if len(args) == 0 || (args[0] != "add" && args[0] != "list" && args[0] != "rm") {
+		if err := parseFlags(fs, args); err != nil {
+			return err
+		}
+		fs.Usage()
+		return errUsage
+	}
+
+	action := args[0]
+	if err := parseFlags(fs, args[1:]); err != nil {
+		return err
+	}
+	// Номер для rm может стоять и перед флагами: rqmc goal rm 2 --config путь
+	var positional []string
+	for fs.NArg() > 0 {
+		positional = append(positional, fs.Arg(0))
+		if err := parseFlags(fs, fs.Args()[1:]); err != nil {
+			return err
+		}
+	}
+	if len(positional) != 0 && (action != "rm" || len(positional) > 1) {
+		fs.Usage()
+		return errUsage
+	}
+
+	cfg, err := ctx.setup(fs, common)
+	if err != nil {
+		return err
+	}
+	dir, err := cfg.Dir()
+	if err != nil {
+		return err
+	}
+	file, err := goals.Load(filepath.Join(dir, goals.FileName))
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "add":
+		if common.profile == config.AllProfiles {
+			return errors.New(i18n.T("цель относится к одному персонажу, укажите --profile имя"))
+		}
+
+		g := goals.Goal{Name: *name, Profile: common.profile, Monster: strings.TrimSpace(*monster)}
+		switch {
+		case (*kills == "") == (*exp == ""):
+			return errors.New(i18n.T("укажите одно из --kills или --exp"))
+		case *kills != "":
+			g.Metric = goals.MetricKills
+			g.Target, err = parseAmount(*kills)
+		default:
+			g.Metric = goals.MetricExp
+			g.Target, err = parseAmount(*exp)
+		}
+		if err != nil {
+			return err
+		}
+		if g.Start, err = parseSince(*since, time.Now()); err != nil {
+			return err
+		}
+
+		g = file.Add(g)
+		if err := file.Save(); err != nil {
+			return i18n.Errorf("ошибка сохранения целей: %w", err)
+		}
+		ctx.printf("Добавлена цель %s\n", describeGoal(g))
+		return nil
+
+	case "rm":
+		if len(positional) == 0 {
+			fs.Usage()
+			return errUsage
+		}
+		id, err := strconv.Atoi(positional[0])
+		if err != nil {
+			return i18n.Errorf("некорректный номер цели %q", positional[0])
+		}
+		if !file.Remove(id) {
+			return i18n.Errorf("цель #%d не найдена", id)
+		}
+		if err := file.Save(); err != nil {
+			return i18n.Errorf("ошибка сохранения целей: %w", err)
+		}
+		ctx.printf("Цель #%d удалена\n", id)
+		return nil
+	}
+
+	progress, err := goalProgress(common.config, common.profile, file.Goals, *gap, *dedupeFlag)
+	if err != nil {
+		return err
+	}
+
+	if common.format == "json" {
+		items := make([]goalJSON, 0, len(progress))
+		for _, p := range progress {
+			items = append(items, newGoalJSON(p))
+		}
+		data, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return i18n.Errorf("ошибка формирования JSON: %w", err)
+		}
+		ctx.printf("%s\n", data)
+		return nil
+	}
+
+	if len(progress) == 0 {
+		ctx.println(i18n.T("Целей нет. Добавьте: rqmc goal add --kills 500 --monster имя"))
+		return nil
+	}
+	for i, p := range progress {
+		if i > 0 {
+			ctx.println()
+		}
+		ctx.println(describeGoal(p.Goal))
+		ctx.println(ctx.formatProgress(p))
+	}
+	return nil
+}
+
+// goalProgress считает прогресс целей; profile, если указан, оставляет
+// только цели этого персонажа. Записи каждого персонажа загружаются с
+// месяца самой ранней из его целей.
+func goalProgress(configPath, profile string, list []goals.Goal, gap time.Duration, removeDuplicates bool) ([]goals.Progress, error) {
+	base, err := loadConfig(configPath, "")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string][]parser.LogEntry)
+	var result []goals.Progress
+	for _, g := range list {
+		if profile != "" && profile != config.AllProfiles && g.Profile != profile {
+			continue
+		}
+
+		if _, ok := entries[g.Profile]; !ok {
+			cfg := base
+			if g.Profile != "" {
+				if cfg, err = base.Profile(g.Profile); err != nil {
+					return nil, err
+				}
+			}
+			loaded, err := loadSince(cfg, earliestStart(list, g.Profile), removeDuplicates)
+			if err != nil {
+				return nil, err
+			}
+			entries[g.Profile] = normalizeEntries(loaded, newNormalizer(cfg))
+		}
+
+		result = append(result, goals.Compute(g, entries[g.Profile], gap))
+	}
+	return result, nil
+}
+
+func earliestStart(list []goals.Goal, profile string) time.Time {
+	var start time.Time
+	for _, g := range list {
+		if g.Profile == profile && (start.IsZero() || g.Start.Before(start)) {
+			start = g.Start
+		}
+	}
+	return start
+}
+
+// loadSince загружает записи всех месяцев, начиная с месяца since.
+func loadSince(cfg *config.Config, since time.Time, removeDuplicates bool) ([]parser.LogEntry, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	files, err := discoverFiles(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	first := since.Format("2006.01")
+	var selected []logfiles.File
+	for _, f := range files {
+		if f.Month >= first {
+			selected = append(selected, f)
+		}
+	}
+
+	entries := loadEntries(selected)
+	if removeDuplicates {
+		entries, _ = dedupe.Remove(entries, dedupe.DefaultMinRun)
+	}
+	return entries, nil
+}
+
+// newGoalTracker загружает цели персонажа для watch. Возвращает nil, если
+// целей нет.
+func newGoalTracker(cfg *config.Config, profile string, normalizer *normalize.Normalizer) (*goals.Tracker, error) {
+	dir, err := cfg.Dir()
+	if err != nil {
+		return nil, err
+	}
+	file, err := goals.Load(filepath.Join(dir, goals.FileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var list []goals.Goal
+	for _, g := range file.Goals {
+		if g.Profile == profile {
+			list = append(list, g)
+		}
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	entries, err := loadSince(cfg, earliestStart(list, profile), false)
+	if err != nil {
+		return nil, err
+	}
+	return goals.NewTracker(list, normalizeEntries(entries, normalizer)), nil
+}
+
+// goalEvent - уведомление о выполненной цели.
+func goalEvent(g goals.Goal, now time.Time) notify.Event {
+	e := notify.Event{Kind: notify.KindGoal, Time: now, Monster: g.Monster, Text: i18n.T("Цель выполнена: %s", describeGoal(g))}
+	if g.Metric == goals.MetricExp {
+		e.Exp = g.Target
+	} else {
+		e.Count = g.Target
+	}
+	return e
+}
+
+func describeGoal(g goals.Goal) string {
+	target := i18n.T("все монстры")
+	if g.Monster != "" {
+		target = g.Monster
+	}
+	if g.Metric == goals.MetricExp {
+		target = i18n.T("%s: опыт %s", target, stats.FormatNumberForDisplay(g.Target))
+	} else {
+		target = i18n.T("%s: убийств %s", target, stats.FormatNumberForDisplay(g.Target))
+	}
+	if g.Name != "" {
+		target = g.Name + " - " + target
+	}
+	if g.Profile != "" {
+		target += " [" + g.Profile + "]"
+	}
+	return i18n.T("#%d %s (с %s)", g.ID, target, g.Start.Format("2006-01-02 15:04"))
+}
+
+func (ctx *Context) formatProgress(p goals.Progress) string {
+	filled := int(math.Round(p.Fraction() * goalBarWidth))
+	bar := "[" + strings.Repeat("█", filled) + strings.Repeat("░", goalBarWidth-filled) + "]"
+	line := fmt.Sprintf("  %s %3d%%  %s / %s", bar, int(p.Fraction()*100),
+		stats.FormatNumberForDisplay(p.Current), stats.FormatNumberForDisplay(p.Target))
+
+	switch {
+	case p.Complete():
+		return ctx.paint(term.Green, line+"  "+i18n.T("выполнена %s", p.CompletedAt.Format("2006-01-02 15:04")))
+	case p.ETA > 0:
+		return line + "  " + i18n.T("осталось ~%s игры (%s в час)", stats.FormatDuration(p.ETA), stats.FormatNumberForDisplay(p.Rate))
+	}
+	return line
+}
+
+func newGoalJSON(p goals.Progress) goalJSON {
+	item := goalJSON{
+		ID:          p.ID,
+		Name:        p.Name,
+		Profile:     p.Profile,
+		Monster:     p.Monster,
+		Metric:      p.Metric,
+		Target:      p.Target,
+		Start:       p.Start.Format("2006-01-02T15:04:05"),
+		Current:     p.Current,
+		Percent:     int(p.Fraction() * 100),
+		RatePerHour: p.Rate,
+		ETAMinutes:  int(p.ETA.Minutes()),
+	}
+	if p.Complete() {
+		item.CompletedAt = p.CompletedAt.Format("2006-01-02T15:04:05")
+	}
+	return item
+}
+
+// parseAmount разбирает положительное число, возможно с суффиксом k
+// (тысячи) или M (миллионы): 500, 2.5k, 10M.
+func parseAmount(value string) (int, error) {
+	s := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), "_", ""))
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(s, "k"):
+		multiplier, s = 1e3, strings.TrimSuffix(s, "k")
+	case strings.HasSuffix(s, "m"):
+		multiplier, s = 1e6, strings.TrimSuffix(s, "m")
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	amount := int(math.Round(n * multiplier))
+	if err != nil || amount <= 0 {
+		return 0, i18n.Errorf("некорректное значение %q: нужно положительное число, например 500 или 10M", value)
+	}
+	return amount, nil
+}
+
+// parseSince разбирает начало отсчёта цели: дату, дату со временем или
+// today, week (с понедельника), month (с первого числа).
+func parseSince(value string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch value {
+	case "", "now":
+		return now.Truncate(time.Second), nil
+	case "today":
+		return today, nil
+	case "week":
+		return today.AddDate(0, 0, -(int(today.Weekday())+6)%7), nil
+	case "month":
+		return today.AddDate(0, 0, 1-today.Day()), nil
+	}
+
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, i18n.Errorf("некорректное начало отсчёта %q: нужно YYYY-MM-DD [HH:MM], today, week или month", value)
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"encoding/json"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/stats"
+)
+
+var heatmapCommand = &Command{
+	Name:    "heatmap",
+	Summary: i18n.N("активность по дням недели и часам"),
+	Usage:   i18n.N("rqmc heatmap [--exp] [--ascii] [--month YYYY.MM | --all] [флаги]"),
+}
+
+func init() {
+	heatmapCommand.Run = runHeatmap
+}
+
+type heatmapJSON struct {
+	Weekdays []string   `json:"weekdays"`
+	Kills    [7][24]int `json:"kills"`
+	Exp      [7][24]int `json:"exp"`
+}
+
+func runHeatmap(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, heatmapCommand)
+	common := addCommonFlags(fs, "table", "json")
+	rng := addRangeFlags(fs)
+	showExp := fs.Bool("exp", false, i18n.T("закрашивать по опыту, а не по убийствам"))
+	ascii := fs.Bool("ascii", false, i18n.T("рисовать символами ASCII вместо блоков Unicode"))
+	filter := fs.String("filter", "", i18n.T("учитывать только монстров, в имени которых есть подстрока"))
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.setup(fs, common)
+	if err != nil {
+		return err
+	}
+
+	sel, err := ctx.loadSelection(cfg, common.profile, rng)
+	if err != nil || sel == nil {
+		return err
+	}
+
+	entries := filterEntries(normalizeEntries(sel.entries, newNormalizer(cfg)), *filter)
+	heatmap := stats.NewHeatmap(entries)
+
+	if common.format == "json" {
+		data, err := json.MarshalIndent(heatmapJSON{
+			Weekdays: []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"},
+			Kills:    heatmap.Kills,
+			Exp:      heatmap.Exp,
+		}, "", "  ")
+		if err != nil {
+			return i18n.Errorf("ошибка формирования JSON: %w", err)
+		}
+		ctx.printf("%s\n", data)
+		return nil
+	}
+
+	shades := stats.UnicodeShades
+	if *ascii {
+		shades = stats.ASCIIShades
+	}
+	ctx.printf("%s", stats.FormatHeatmap(heatmap, *showExp, shades))
+	return nil
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/hooks"
+	"RQ_MobCounter/live"
+	"RQ_MobCounter/normalize"
+	"RQ_MobCounter/parser"
+)
+
+// watchHooks запускает команды из секции hooks конфига при событиях watch.
+// Методы nil-безопасны: nil означает, что команд нет или они выключены.
+type watchHooks struct {
+	mu       sync.Mutex
+	ctx      context.Context
+	detector *hooks.Detector
+	runner   *hooks.Runner
+	closed   bool
+}
+
+// newWatchHooks ожидает уже проверенный конфиг.
+func newWatchHooks(cfg config.HooksConfig, gap time.Duration, normalizer *normalize.Normalizer, stderr io.Writer) *watchHooks {
+	if len(cfg.Commands) == 0 {
+		return nil
+	}
+
+	timeout, _ := config.HookTimeout(cfg.Timeout)
+	var list []hooks.Hook
+	for _, c := range cfg.Commands {
+		h := hooks.Hook{Event: c.Event, Command: c.Command, Timeout: timeout}
+		if c.Monster != "" {
+			h.Monster = normalizer.Name(c.Monster)
+		}
+		if t, _ := config.HookTimeout(c.Timeout); t > 0 {
+			h.Timeout = t
+		}
+		list = append(list, h)
+	}
+
+	return &watchHooks{
+		ctx:      context.Background(),
+		detector: hooks.NewDetector(gap),
+		runner:   hooks.New(list, cfg.MaxConcurrent, stderr),
+	}
+}
+
+// start проверяет конец сессии раз в минуту до отмены ctx. Команды,
+// работающие в момент отмены, останавливаются.
+func (h *watchHooks) start(ctx context.Context) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	h.ctx = ctx
+	h.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				h.mu.Lock()
+				h.fire(h.detector.Tick(now))
+				h.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// seed учитывает записи, которые были в логе до запуска.
+func (h *watchHooks) seed(entries []parser.LogEntry, now time.Time) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.detector.Seed(entries, now)
+}
+
+// observe обрабатывает обновление лога; entries - его нормализованные записи.
+func (h *watchHooks) observe(update live.Update, entries []parser.LogEntry, now time.Time) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if update.Rollover {
+		h.fire([]hooks.Event{{Type: hooks.EventRollover, Time: now, Month: update.Month}})
+	}
+	h.fire(h.detector.Observe(entries, update.Levels))
+}
+
+// wait ждёт завершения команд из очереди, чтобы их вывод попал в лог.
+// После wait события больше не обрабатываются.
+func (h *watchHooks) wait() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	h.runner.Close()
+}
+
+func (h *watchHooks) fire(events []hooks.Event) {
+	if h.closed {
+		return
+	}
+	for _, e := range events {
+		h.runner.Fire(h.ctx, e)
+	}
+}
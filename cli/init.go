@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/logfiles"
+	"RQ_MobCounter/term"
+)
+
+const searchDepth = 4
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+var initCommand = &Command{
+	Name:    "init",
+	Summary: i18n.N("мастер первоначальной настройки: поиск папки chatlogs и создание конфига"),
+	Usage:   i18n.N("rqmc init [--root папка] [--log-path путь] [--prefix префикс] [--config путь | --here] [--yes]"),
+}
+
+func init() {
+	initCommand.Run = runInit
+}
+
+func runInit(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, initCommand)
+	var roots stringList
+	fs.Var(&roots, "root", i18n.T("дополнительная папка для поиска chatlogs (можно указать несколько раз)"))
+	logPath := fs.String("log-path", "", i18n.T("путь к папке с логами (без поиска)"))
+	prefix := fs.String("prefix", "", i18n.T("префикс файлов логов (без определения)"))
+	configPath := fs.String("config", "", i18n.T("куда сохранить конфиг"))
+	here := fs.Bool("here", false, i18n.T("сохранить конфиг в текущей папке (./config.json)"))
+	yes := fs.Bool("yes", false, i18n.T("не задавать вопросов: выбрать первую найденную папку и самый частый префикс"))
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	in := bufio.NewReader(ctx.Stdin)
+	interactive := !*yes
+
+	path := *logPath
+	if path == "" {
+		var err error
+		path, err = ctx.chooseLogPath(in, roots, interactive)
+		if err != nil {
+			return err
+		}
+	}
+
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return i18n.Errorf("папка с логами не найдена: %s", path)
+	}
+
+	filePrefix := *prefix
+	if filePrefix == "" {
+		var err error
+		filePrefix, err = ctx.choosePrefix(in, path, interactive)
+		if err != nil {
+			return err
+		}
+	}
+
+	target, err := initTarget(*configPath, *here)
+	if err != nil {
+		return err
+	}
+
+	// Существующий файл читается без переменных окружения RQMC_*, иначе
+	// их значения навсегда попали бы в конфиг
+	cfg, err := config.ReadFile(target)
+	exists := err == nil
+	if errors.Is(err, os.ErrNotExist) {
+		cfg, err = &config.Config{}, nil
+	}
+	if err != nil {
+		return i18n.Errorf("ошибка загрузки существующего конфига: %w", err)
+	}
+	cfg.LogPath = path
+	cfg.FilePrefix = filePrefix
+
+	ctx.println()
+	ctx.printf("Папка с логами: %s\n", cfg.LogPath)
+	ctx.printf("Префикс файлов: %s\n", cfg.FilePrefix)
+	if exists {
+		ctx.printf("Конфиг будет обновлён: %s\n", target)
+	} else {
+		ctx.printf("Конфиг будет создан: %s\n", target)
+	}
+
+	if interactive && !ctx.confirm(in, i18n.T("Сохранить?")) {
+		ctx.println(i18n.T("Отменено"))
+		return nil
+	}
+
+	if err := cfg.SaveTo(target); err != nil {
+		return err
+	}
+
+	ctx.println(ctx.paint(term.Green, i18n.T("Конфиг сохранён: %s", cfg.Path())))
+	ctx.println(i18n.T("Теперь можно запускать: rqmc --exp"))
+	return nil
+}
+
+// initTarget выбирает файл для init: --config, ./config.json с --here,
+// уже существующий конфиг или новый файл в папке настроек пользователя.
+// Папка приложения не подходит: при go run это временная папка.
+func initTarget(explicit string, here bool) (string, error) {
+	switch {
+	case explicit != "":
+		return explicit, nil
+	case here:
+		return filepath.Abs(config.FileName)
+	}
+
+	found, err := config.Locate("")
+	if err != nil {
+		return "", i18n.Errorf("ошибка поиска конфига: %w", err)
+	}
+	if found != "" {
+		return found, nil
+	}
+	return config.UserPath()
+}
+
+func (ctx *Context) chooseLogPath(in *bufio.Reader, roots []string, interactive bool) (string, error) {
+	searchRoots := append(append([]string{}, roots...), logfiles.DefaultSearchRoots()...)
+
+	ctx.println(i18n.T("Поиск папки chatlogs..."))
+	found := logfiles.FindChatlogDirs(searchRoots, searchDepth)
+
+	if len(found) == 0 {
+		if !interactive {
+			return "", errors.New(i18n.T("папка chatlogs не найдена, укажите её через --log-path или --root"))
+		}
+		ctx.println(ctx.paint(term.Yellow, i18n.T("Папка chatlogs не найдена автоматически.")))
+		return ctx.ask(in, i18n.T("Введите путь к папке с логами")), nil
+	}
+
+	if !interactive {
+		return found[0], nil
+	}
+
+	ctx.println(i18n.T("Найдены папки с логами:"))
+	for i, dir := range found {
+		ctx.printf("  %d. %s\n", i+1, dir)
+	}
+
+	answer := ctx.ask(in, i18n.T("Выберите номер или введите свой путь [1]"))
+	if answer == "" {
+		return found[0], nil
+	}
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(found) {
+		return found[n-1], nil
+	}
+	return filepath.Clean(answer), nil
+}
+
+func (ctx *Context) choosePrefix(in *bufio.Reader, dir string, interactive bool) (string, error) {
+	prefixes, err := logfiles.Prefixes(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if len(prefixes) == 0 {
+		ctx.println(ctx.paint(term.Yellow, i18n.T("В папке нет файлов вида «префикс (YYYY.MM).htm», используется префикс %s", config.DefaultFilePrefix)))
+		return config.DefaultFilePrefix, nil
+	}
+
+	if !interactive || len(prefixes) == 1 {
+		ctx.printf("Найден префикс: %s (месяцев: %d)\n", prefixes[0].Prefix, len(prefixes[0].Months))
+		return prefixes[0].Prefix, nil
+	}
+
+	ctx.println(i18n.T("Найдены префиксы файлов:"))
+	for i, p := range prefixes {
+		ctx.printf("  %d. %s (месяцев: %d, %s - %s)\n", i+1, p.Prefix, len(p.Months), p.Months[0], p.Months[len(p.Months)-1])
+	}
+
+	answer := ctx.ask(in, i18n.T("Выберите номер [1]"))
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(prefixes) {
+		return prefixes[n-1].Prefix, nil
+	}
+	return prefixes[0].Prefix, nil
+}
+
+func (ctx *Context) ask(in *bufio.Reader, question string) string {
+	ctx.printf("%s: ", question)
+	answer, err := in.ReadString('\n')
+	if err != nil && answer == "" {
+		return ""
+	}
+	return strings.TrimSpace(answer)
+}
+
+func (ctx *Context) confirm(in *bufio.Reader, question string) bool {
+	answer := strings.ToLower(ctx.ask(in, question+" [Y/n]"))
+	return answer == "" || answer == "y" || answer == "yes" || answer == "д" || answer == "да"
+}
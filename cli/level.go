@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/levels"
+	"RQ_MobCounter/stats"
+	"RQ_MobCounter/term"
+)
+
+var levelCommand = &Command{
+	Name:    "level",
+	Summary: i18n.N("история уровней персонажа и время до следующего уровня"),
+	Usage:   i18n.N("rqmc level [--gap 15m] [--month YYYY.MM | --all] [флаги]"),
+}
+
+func init() {
+	levelCommand.Run = runLevel
+}
+
+type levelJSON struct {
+	Level       int    `json:"level"`
+	Reached     string `json:"reached"`
+	Left        string `json:"left,omitempty"`
+	Kills       int    `json:"kills"`
+	Exp         int    `json:"exp"`
+	PlayMinutes int    `json:"play_minutes"`
+}
+
+type levelCurrentJSON struct {
+	Level      int `json:"level"`
+	Gained     int `json:"gained"`
+	Need       int `json:"need,omitempty"`
+	Remaining  int `json:"remaining,omitempty"`
+	ExpPerHour int `json:"exp_per_hour"`
+	ETAMinutes int `json:"eta_minutes,omitempty"`
+}
+
+type levelReportJSON struct {
+	Levels  []levelJSON       `json:"levels"`
+	Current *levelCurrentJSON `json:"current,omitempty"`
+}
+
+func runLevel(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, levelCommand)
+	common := addCommonFlags(fs, "table", "json")
+	rng := addRangeFlags(fs)
+	gap := fs.Duration("gap", stats.DefaultSessionGap, i18n.T("перерыв, после которого начинается новая сессия"))
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if common.profile == config.AllProfiles {
+		return errors.New(i18n.T("level работает с одним профилем, укажите --profile имя"))
+	}
+
+	cfg, err := ctx.setup(fs, common)
+	if err != nil {
+		return err
+	}
+	table, err := loadLevelTable(cfg)
+	if err != nil {
+		return err
+	}
+
+	sel, err := ctx.loadSelection(cfg, common.profile, rng)
+	if err != nil || sel == nil {
+		return err
+	}
+
+	steps := levels.Timeline(sel.levels, sel.entries, *gap)
+	estimate, ok := levels.Current(steps, table, sel.entries, *gap)
+
+	if common.format == "json" {
+		report := levelReportJSON{Levels: []levelJSON{}}
+		for _, s := range steps {
+			item := levelJSON{
+				Level:       s.Level,
+				Reached:     s.Reached.Format("2006-01-02T15:04:05"),
+				Kills:       s.Kills,
+				Exp:         s.Exp,
+				PlayMinutes: int(s.PlayTime.Minutes()),
+			}
+			if s.Complete() {
+				item.Left = s.Left.Format("2006-01-02T15:04:05")
+			}
+			report.Levels = append(report.Levels, item)
+		}
+		if ok {
+			report.Current = &levelCurrentJSON{
+				Level:      estimate.Level,
+				Gained:     estimate.Gained,
+				Need:       estimate.Need,
+				Remaining:  estimate.Remaining(),
+				ExpPerHour: estimate.Rate,
+				ETAMinutes: int(estimate.ETA().Minutes()),
+			}
+		}
+
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return i18n.Errorf("ошибка формирования JSON: %w", err)
+		}
+		ctx.printf("%s\n", data)
+		return nil
+	}
+
+	if !ok {
+		ctx.println(i18n.T("В выбранных логах нет сообщений «Вы достигли N уровня!». Попробуйте --all"))
+		return nil
+	}
+
+	ctx.printf("%s", levels.FormatTable(steps))
+	ctx.println()
+	if estimate.Need == 0 {
+		ctx.println(ctx.paint(term.Green, i18n.T("Уровень %d, опыт с повышения %s", estimate.Level, stats.FormatNumberForDisplay(estimate.Gained))))
+		ctx.println(i18n.T("Опыт для %d уровня неизвестен: добавьте строку «%d,опыт» в %s", estimate.Level, estimate.Level, levelTablePath(cfg)))
+		return nil
+	}
+
+	ctx.println(ctx.paint(term.Green, i18n.T("Уровень %d: %s / %s опыта (%d%%), осталось %s",
+		estimate.Level, stats.FormatNumberForDisplay(estimate.Gained), stats.FormatNumberForDisplay(estimate.Need),
+		int(estimate.Fraction()*100), stats.FormatNumberForDisplay(estimate.Remaining()))))
+	if eta := estimate.ETA(); eta > 0 {
+		ctx.println(i18n.T("До %d уровня: ~%s игры при %s опыта в час", estimate.Level+1, stats.FormatDuration(eta), stats.FormatNumberForDisplay(estimate.Rate)))
+	}
+	return nil
+}
+
+// levelTablePath - файл с таблицей опыта, дополняющий встроенную.
+func levelTablePath(cfg *config.Config) string {
+	dir, err := cfg.Dir()
+	if err != nil {
+		return levels.FileName
+	}
+	return filepath.Join(dir, levels.FileName)
+}
+
+// loadLevelTable возвращает встроенную таблицу опыта, дополненную файлом
+// levels.csv из папки конфига, если он есть.
+func loadLevelTable(cfg *config.Config) (levels.Table, error) {
+	table := levels.Default()
+
+	path := levelTablePath(cfg)
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return table, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	custom, err := levels.ParseTable(f)
+	if err != nil {
+		return nil, i18n.Errorf("%s: %w", path, err)
+	}
+	return table.Merge(custom), nil
+}
@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/logfiles"
+	"RQ_MobCounter/stats"
+	"RQ_MobCounter/term"
+)
+
+var monthsCommand = &Command{
+	Name:    "months",
+	Summary: i18n.N("сводка по месяцам: размер файла, строки, убийства, опыт и дни без записей"),
+	Usage:   i18n.N("rqmc months [--format table|json] [--profile имя|all]"),
+}
+
+func init() {
+	monthsCommand.Run = runMonths
+}
+
+type monthJSON struct {
+	Month     string   `json:"month"`
+	Character string   `json:"character,omitempty"`
+	File      string   `json:"file"`
+	Size      int64    `json:"size"`
+	Rows      int      `json:"rows"`
+	Kills     int      `json:"kills"`
+	Exp       int      `json:"exp"`
+	First     string   `json:"first,omitempty"`
+	Last      string   `json:"last,omitempty"`
+	GapDays   []string `json:"gap_days"`
+}
+
+type profileMonths struct {
+	name   string
+	months []stats.MonthSummary
+}
+
+func runMonths(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, monthsCommand)
+	common := addCommonFlags(fs, "table", "json")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.setup(fs, common)
+	if err != nil {
+		return err
+	}
+
+	results, err := collectMonths(cfg, common.profile, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if common.format == "json" {
+		data, err := json.MarshalIndent(monthItems(results), "", "  ")
+		if err != nil {
+			return i18n.Errorf("ошибка формирования JSON: %w", err)
+		}
+		ctx.printf("%s\n", data)
+		return nil
+	}
+
+	for i, r := range results {
+		if r.name != "" {
+			if i > 0 {
+				ctx.println()
+			}
+			ctx.println(ctx.paint(term.Yellow, "=== "+r.name+" ==="))
+		}
+		ctx.printf("%s", stats.FormatMonthTable(r.months))
+	}
+	return nil
+}
+
+// collectMonths собирает сводку по месяцам выбранного профиля или, при
+// profile == all, всех профилей конфига.
+func collectMonths(cfg *config.Config, profile string, now time.Time) ([]profileMonths, error) {
+	if profile != config.AllProfiles {
+		months, err := inventory(cfg, now)
+		if err != nil {
+			return nil, err
+		}
+		return []profileMonths{{months: months}}, nil
+	}
+
+	names := cfg.ProfileNames()
+	if len(names) == 0 {
+		return nil, errors.New(i18n.T("в конфиге нет секции profiles"))
+	}
+
+	var results []profileMonths
+	for _, name := range names {
+		profileCfg, err := cfg.Profile(name)
+		if err != nil {
+			return nil, err
+		}
+		months, err := inventory(profileCfg, now)
+		if err != nil {
+			log.Print(i18n.T("профиль %s пропущен: %v", name, err))
+			continue
+		}
+		results = append(results, profileMonths{name: name, months: months})
+	}
+	return results, nil
+}
+
+// inventory собирает сводку по всем месяцам, найденным в папке логов
+// профиля. Файлы, которые не удалось прочитать, пропускаются.
+func inventory(cfg *config.Config, now time.Time) ([]stats.MonthSummary, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	files, err := discoverFiles(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	months := make([]stats.MonthSummary, 0, len(files))
+	for _, file := range files {
+		info, err := logfiles.Inspect(file)
+		if err != nil {
+			log.Print(i18n.T("ошибка при чтении %s: %v", file.Name(), err))
+			continue
+		}
+
+		summary, err := stats.SummarizeMonth(file.Month, info.Entries, now)
+		if err != nil {
+			return nil, err
+		}
+		summary.Source = file.Name()
+		summary.Size = info.Size
+		summary.Rows = info.Rows
+		months = append(months, summary)
+	}
+
+	return months, nil
+}
+
+func monthItems(results []profileMonths) []monthJSON {
+	items := []monthJSON{}
+	for _, r := range results {
+		for _, m := range r.months {
+			items = append(items, newMonthJSON(r.name, m))
+		}
+	}
+	return items
+}
+
+func newMonthJSON(character string, m stats.MonthSummary) monthJSON {
+	item := monthJSON{
+		Month:     m.Month,
+		Character: character,
+		File:      m.Source,
+		Size:      m.Size,
+		Rows:      m.Rows,
+		Kills:     m.Kills,
+		Exp:       m.TotalExp,
+		GapDays:   []string{},
+	}
+	if !m.First.IsZero() {
+		item.First = m.First.Format("2006-01-02T15:04:05")
+		item.Last = m.Last.Format("2006-01-02T15:04:05")
+	}
+	for _, day := range m.GapDays {
+		item.GapDays = append(item.GapDays, day.Format("2006-01-02"))
+	}
+	return item
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/live"
+	"RQ_MobCounter/normalize"
+	"RQ_MobCounter/notify"
+	"RQ_MobCounter/parser"
+)
+
+// watchNotifier отправляет уведомления о событиях watch в фоне, чтобы
+// повторы запросов не задерживали чтение лога. Методы nil-безопасны:
+// nil означает, что уведомления выключены.
+type watchNotifier struct {
+	mu       sync.Mutex
+	tracker  *notify.Tracker
+	notifier *notify.Notifier
+	queue    chan notify.Event
+	stderr   io.Writer
+}
+
+func newWatchNotifier(cfg config.NotifyConfig, normalizer *normalize.Normalizer, stderr io.Writer) *watchNotifier {
+	if len(cfg.Webhooks) == 0 {
+		return nil
+	}
+
+	rules := notify.Rules{
+		Milestones: cfg.Milestones,
+		LevelUp:    cfg.LevelUp,
+		Hourly:     cfg.Hourly,
+	}
+	for _, name := range cfg.Rare {
+		rules.Rare = append(rules.Rare, normalizer.Name(name))
+	}
+
+	var webhooks []notify.Webhook
+	for _, w := range cfg.Webhooks {
+		webhooks = append(webhooks, notify.Webhook{URL: w.URL, Format: w.Format, ChatID: w.ChatID, Events: w.Events})
+	}
+
+	return &watchNotifier{
+		tracker:  notify.NewTracker(rules),
+		notifier: notify.New(webhooks),
+		queue:    make(chan notify.Event, 100),
+		stderr:   stderr,
+	}
+}
+
+// start запускает отправку и почасовые сводки до отмены ctx.
+func (n *watchNotifier) start(ctx context.Context) {
+	if n == nil {
+		return
+	}
+
+	n.tick(time.Now())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e := <-n.queue:
+				if err := n.notifier.Deliver(ctx, e); err != nil && ctx.Err() == nil {
+					fmt.Fprintln(n.stderr, i18n.T("ошибка отправки уведомления: %v", err))
+				}
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				n.tick(now)
+			}
+		}
+	}()
+}
+
+// seed учитывает записи, которые были в логе до запуска.
+func (n *watchNotifier) seed(entries []parser.LogEntry) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.tracker.Seed(entries)
+}
+
+// observe обрабатывает обновление лога; entries - его нормализованные записи.
+func (n *watchNotifier) observe(update live.Update, entries []parser.LogEntry) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if update.Rollover {
+		n.tracker.Reset()
+	}
+	n.enqueue(n.tracker.Observe(entries, update.Levels))
+}
+
+// send отправляет событие, созданное вне Tracker, например о выполненной цели.
+func (n *watchNotifier) send(e notify.Event) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.enqueue([]notify.Event{e})
+}
+
+func (n *watchNotifier) tick(now time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.enqueue(n.tracker.Tick(now))
+}
+
+func (n *watchNotifier) enqueue(events []notify.Event) {
+	for _, e := range events {
+		select {
+		case n.queue <- e:
+		default:
+			fmt.Fprintln(n.stderr, i18n.T("очередь уведомлений переполнена, пропущено: %s", e.Text))
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/live"
+	"RQ_MobCounter/parser"
+	"RQ_MobCounter/stats"
+)
+
+// Файлы оверлея, которые можно положить рядом с конфигом вместо встроенных.
+const (
+	overlayTemplateFile = "overlay.html"
+	overlayStyleFile    = "overlay.css"
+)
+
+// overlayState - текущая сессия в том виде, в котором её показывает
+// оверлей. Числа уже отформатированы.
+type overlayState struct {
+	Kills       string `json:"kills"`
+	Exp         string `json:"exp"`
+	ExpPerHour  string `json:"exp_per_hour"`
+	Duration    string `json:"duration"`
+	LastMonster string `json:"last_monster"`
+}
+
+// overlayPage - данные шаблона overlay.html. Lang и L - язык и подписи
+// из конфига или окружения.
+type overlayPage struct {
+	overlayState
+	Events string
+	Lang   string
+	L      overlayLabels
+}
+
+type overlayLabels struct {
+	Kills, ExpPerHour, LastMonster string
+}
+
+// newOverlayState считает текущую сессию. Если с последнего убийства
+// прошло больше gap, сессия закончилась и показываются нули.
+func newOverlayState(entries []parser.LogEntry, gap time.Duration, now time.Time) overlayState {
+	var s stats.Session
+	if sessions := stats.Sessions(entries, gap); len(sessions) > 0 {
+		if last := sessions[len(sessions)-1]; now.Sub(last.End) <= gap {
+			s = last
+		}
+	}
+
+	return overlayState{
+		Kills:       stats.FormatNumberForDisplay(s.KillCount),
+		Exp:         stats.FormatNumberForDisplay(s.TotalExp),
+		ExpPerHour:  stats.FormatNumberForDisplay(s.ExpPerHour()),
+		Duration:    stats.FormatDuration(s.Duration()),
+		LastMonster: s.LastKill,
+	}
+}
+
+// overlayQuery разбирает параметры запроса оверлея: нужен один профиль.
+func (d *dashboard) overlayQuery(w http.ResponseWriter, r *http.Request) *apiQuery {
+	q, err := d.query(r)
+	if err == nil && q.profile == config.AllProfiles {
+		err = errors.New(i18n.T("оверлей работает с одним профилем, укажите profile=имя"))
+	}
+	if err == nil {
+		err = q.cfg.Validate()
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return nil
+	}
+	return q
+}
+
+// overlayFile возвращает файл оверлея из папки конфига или, если его там
+// нет, встроенный.
+func (d *dashboard) overlayFile(name string) ([]byte, error) {
+	if d.cfg.Path() != "" {
+		data, err := os.ReadFile(filepath.Join(filepath.Dir(d.cfg.Path()), name))
+		if err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return data, err
+		}
+	}
+	return webFiles.ReadFile("web/" + name)
+}
+
+func (d *dashboard) handleOverlay(w http.ResponseWriter, r *http.Request) {
+	q := d.overlayQuery(w, r)
+	if q == nil {
+		return
+	}
+
+	source, err := d.overlayFile(overlayTemplateFile)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	tmpl, err := template.New(overlayTemplateFile).Parse(string(source))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	update, err := live.New(q.cfg.LogPath, q.cfg.FilePrefix).Poll()
+	if err != nil {
+		log.Print(i18n.T("ошибка чтения лога: %v", err))
+	}
+
+	page := overlayPage{
+		overlayState: newOverlayState(normalizeEntries(update.Entries, newNormalizer(q.cfg)), q.gap, time.Now()),
+		Events:       "/overlay/events?" + r.URL.RawQuery,
+		Lang:         i18n.Language(),
+		L: overlayLabels{
+			Kills:       i18n.T("Убийств"),
+			ExpPerHour:  i18n.T("Опыт/час"),
+			LastMonster: i18n.T("Последний"),
+		},
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tmpl.Execute(w, page); err != nil {
+		log.Print(err)
+	}
+}
+
+func (d *dashboard) handleOverlayStyle(w http.ResponseWriter, r *http.Request) {
+	data, err := d.overlayFile(overlayStyleFile)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/css; charset=utf-8")
+	w.Write(data)
+}
+
+// handleOverlayEvents отправляет состояние сессии через Server-Sent Events:
+// сразу после подключения, при каждом появлении новых строк в логе
+// текущего месяца и при изменении состояния со временем, например когда
+// сессия заканчивается через gap без новых убийств.
+func (d *dashboard) handleOverlayEvents(w http.ResponseWriter, r *http.Request) {
+	q := d.overlayQuery(w, r)
+	if q == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	rc := http.NewResponseController(w)
+
+	normalizer := newNormalizer(q.cfg)
+	watcher := live.New(q.cfg.LogPath, q.cfg.FilePrefix)
+
+	var (
+		entries []parser.LogEntry
+		last    []byte
+	)
+	send := func() bool {
+		data, err := json.Marshal(newOverlayState(entries, q.gap, time.Now()))
+		if err != nil {
+			return false
+		}
+		if bytes.Equal(data, last) {
+			return true
+		}
+		last = data
+		if _, err := fmt.Fprintf(w, "event: session\ndata: %s\n\n", data); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	update, err := watcher.Poll()
+	if err != nil {
+		log.Print(i18n.T("ошибка чтения лога: %v", err))
+	}
+	entries = normalizeEntries(update.Entries, normalizer)
+	if !send() {
+		return
+	}
+
+	ctx := r.Context()
+	updates := make(chan live.Update)
+	go watcher.Run(ctx, d.interval, func(update live.Update) {
+		select {
+		case updates <- update:
+		case <-ctx.Done():
+		}
+	}, func(err error) {
+		log.Print(i18n.T("ошибка чтения лога: %v", err))
+	})
+
+	// Без новых строк состояние всё равно пересчитывается: иначе оверлей
+	// показывал бы закончившуюся сессию до следующего убийства
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update := <-updates:
+			if update.Rollover || update.Reset {
+				entries = nil
+			}
+			entries = append(entries, normalizeEntries(update.Entries, normalizer)...)
+		case <-ticker.C:
+		}
+		if !send() {
+			return
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"time"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/report"
+	"RQ_MobCounter/stats"
+	"RQ_MobCounter/term"
+)
+
+var reportCommand = &Command{
+	Name:    "report",
+	Summary: i18n.N("отчёт в файл: календарь активности в SVG или страница HTML с графиками"),
+	Usage:   i18n.N("rqmc report (--svg файл | --html файл) [--exp] [--gap 15m] [--month YYYY.MM | --all] [флаги]"),
+}
+
+func init() {
+	reportCommand.Run = runReport
+}
+
+func runReport(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, reportCommand)
+	common := addCommonFlags(fs)
+	rng := addRangeFlags(fs)
+	svgPath := fs.String("svg", "", i18n.T("сохранить календарь активности в SVG"))
+	htmlPath := fs.String("html", "", i18n.T("сохранить отчёт с графиками и таблицами в HTML"))
+	showExp := fs.Bool("exp", false, i18n.T("закрашивать календарь SVG по опыту, а не по убийствам"))
+	gap := fs.Duration("gap", stats.DefaultSessionGap, i18n.T("перерыв, после которого начинается новая сессия"))
+	filter := fs.String("filter", "", i18n.T("учитывать только монстров, в имени которых есть подстрока"))
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *svgPath == "" && *htmlPath == "" {
+		return errors.New(i18n.T("укажите файл отчёта: --svg файл или --html файл"))
+	}
+
+	cfg, err := ctx.setup(fs, common)
+	if err != nil {
+		return err
+	}
+
+	sel, err := ctx.loadSelection(cfg, common.profile, rng)
+	if err != nil || sel == nil {
+		return err
+	}
+	entries := filterEntries(normalizeEntries(sel.entries, newNormalizer(cfg)), *filter)
+
+	if *svgPath != "" {
+		var buf bytes.Buffer
+		if err := report.Calendar(&buf, stats.Daily(entries), *showExp); err != nil {
+			return err
+		}
+		if err := writeReport(*svgPath, buf.Bytes()); err != nil {
+			return err
+		}
+		ctx.println(ctx.paint(term.Green, i18n.T("Календарь сохранён в %s", *svgPath)))
+	}
+
+	if *htmlPath != "" {
+		// Таблица монстров та же, что у rqmc stats без ограничения строк
+		calculator := stats.NewCalculator(entries)
+		data := report.Report{
+			Generated: time.Now(),
+			Monsters:  calculator.Calculate("count", 0),
+			Days:      stats.Daily(entries),
+			Sessions:  stats.Sessions(entries, *gap),
+		}
+		var buf bytes.Buffer
+		if err := report.HTML(&buf, data); err != nil {
+			return err
+		}
+		if err := writeReport(*htmlPath, buf.Bytes()); err != nil {
+			return err
+		}
+		ctx.println(ctx.paint(term.Green, i18n.T("Отчёт сохранён в %s", *htmlPath)))
+	}
+	return nil
+}
+
+func writeReport(path string, data []byte) error {
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return i18n.Errorf("ошибка записи %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+package cli
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"html/template"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/live"
+	"RQ_MobCounter/parser"
+	"RQ_MobCounter/stats"
+)
+
+var serveCommand = &Command{
+	Name:    "serve",
+	Summary: i18n.N("локальный веб-дашборд и JSON API со статистикой"),
+	Usage:   i18n.N("rqmc serve [--addr 127.0.0.1:8080] [--config путь] [--profile имя|all]"),
+}
+
+func init() {
+	serveCommand.Run = runServe
+}
+
+const defaultServeAddr = "127.0.0.1:8080"
+
+//go:embed web
+var webFiles embed.FS
+
+var dashboardTemplate = template.Must(template.ParseFS(webFiles, "web/index.html"))
+
+// dashboardPage - данные шаблона index.html. Подписи переводятся на язык
+// из конфига или окружения, Script - подписи для скрипта страницы.
+type dashboardPage struct {
+	Lang   string
+	L      dashboardLabels
+	Script map[string]string
+}
+
+type dashboardLabels struct {
+	Month, Filter, FilterHint, Dedupe        string
+	Kills, Exp, MonsterCount, Days, Monsters string
+	Monster, Count, TotalExp, AvgExp         string
+}
+
+type dayJSON struct {
+	Date  string `json:"date"`
+	Kills int    `json:"kills"`
+	Exp   int    `json:"exp"`
+}
+
+func runServe(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, serveCommand)
+	common := addCommonFlags(fs)
+	addr := fs.String("addr", defaultServeAddr, i18n.T("адрес и порт сервера"))
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	// Дашборд переключает профили на каждый запрос, поэтому ему нужен весь
+	// конфиг, а флаги берут значения из выбранного профиля
+	cfg, err := loadConfig(common.config, "")
+	if err != nil {
+		return err
+	}
+	flagsCfg := cfg
+	if common.profile != "" && common.profile != config.AllProfiles {
+		if flagsCfg, err = cfg.Profile(common.profile); err != nil {
+			return err
+		}
+	}
+	if err := ctx.applyFlags(fs, common, flagsCfg); err != nil {
+		return err
+	}
+
+	d := newDashboard(cfg, common.profile)
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Запросы наследуют sigCtx, чтобы по Ctrl+C завершились и потоки событий
+	// оверлея - иначе Shutdown ждал бы их бесконечно
+	server := &http.Server{
+		Handler:           d.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return sigCtx },
+	}
+	go func() {
+		<-sigCtx.Done()
+		server.Shutdown(context.Background())
+	}()
+
+	ctx.printf("Дашборд: http://%s (Ctrl+C для выхода)\n", listener.Addr())
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// dashboard отдаёт встроенную страницу и JSON API. Конфиг загружается один
+// раз при запуске, а логи читаются заново при каждом запросе.
+type dashboard struct {
+	cfg      *config.Config
+	profile  string
+	interval time.Duration
+}
+
+func newDashboard(cfg *config.Config, profile string) *dashboard {
+	return &dashboard{cfg: cfg, profile: profile, interval: live.DefaultInterval}
+}
+
+func (d *dashboard) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", d.handleIndex)
+	mux.HandleFunc("GET /api/stats", d.handleStats)
+	mux.HandleFunc("GET /api/days", d.handleDays)
+	mux.HandleFunc("GET /api/sessions", d.handleSessions)
+	mux.HandleFunc("GET /api/months", d.handleMonths)
+	mux.HandleFunc("GET /overlay", d.handleOverlay)
+	mux.HandleFunc("GET /overlay/overlay.css", d.handleOverlayStyle)
+	mux.HandleFunc("GET /overlay/events", d.handleOverlayEvents)
+	return mux
+}
+
+func (d *dashboard) handleIndex(w http.ResponseWriter, r *http.Request) {
+	page := dashboardPage{
+		Lang: i18n.Language(),
+		L: dashboardLabels{
+			Month:        i18n.T("Месяц"),
+			Filter:       i18n.T("Фильтр"),
+			FilterHint:   i18n.T("имя монстра"),
+			Dedupe:       i18n.T("без дубликатов"),
+			Kills:        i18n.T("Убийств"),
+			Exp:          i18n.T("Опыт"),
+			MonsterCount: i18n.T("Монстров"),
+			Days:         i18n.T("По дням"),
+			Monsters:     i18n.T("Монстры"),
+			Monster:      i18n.T("Монстр"),
+			Count:        i18n.T("Количество"),
+			TotalExp:     i18n.T("Суммарный опыт"),
+			AvgExp:       i18n.T("Средний опыт"),
+		},
+		Script: map[string]string{
+			"allMonths": i18n.T("Все месяцы"),
+			"noData":    i18n.T("Нет данных для отображения"),
+			"dayTitle":  i18n.T("%s: убийств %s, опыт %s"),
+		},
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := dashboardTemplate.Execute(w, page); err != nil {
+		log.Print(err)
+	}
+}
+
+// apiQuery - параметры запроса. Они называются так же, как флаги команд
+// (month, all, dedupe, profile, sort, limit, filter, min-count, gap), а
+// не указанные берутся из defaults конфига.
+type apiQuery struct {
+	cfg      *config.Config
+	profile  string
+	rng      *rangeFlags
+	sort     string
+	limit    int
+	filter   string
+	minCount int
+	gap      time.Duration
+}
+
+func (d *dashboard) query(r *http.Request) (*apiQuery, error) {
+	fs := flag.NewFlagSet("api", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	q := &apiQuery{}
+	fs.StringVar(&q.profile, "profile", d.profile, "")
+	q.rng = addRangeFlags(fs)
+	fs.StringVar(&q.sort, "sort", "count", "")
+	fs.IntVar(&q.limit, "limit", 20, "")
+	fs.StringVar(&q.filter, "filter", "", "")
+	fs.IntVar(&q.minCount, "min-count", 0, "")
+	fs.DurationVar(&q.gap, "gap", stats.DefaultSessionGap, "")
+
+	for name, values := range r.URL.Query() {
+		f := fs.Lookup(name)
+		if f == nil {
+			return nil, i18n.Errorf("неизвестный параметр %q", name)
+		}
+
+		// ?all равносильно флагу --all без значения
+		value := values[len(values)-1]
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() && value == "" {
+			value = "true"
+		}
+		if err := fs.Set(name, value); err != nil {
+			return nil, i18n.Errorf("некорректное значение %s=%q: %w", name, value, err)
+		}
+	}
+
+	q.cfg = d.cfg
+	if q.profile != "" && q.profile != config.AllProfiles {
+		profileCfg, err := d.cfg.Profile(q.profile)
+		if err != nil {
+			return nil, err
+		}
+		q.cfg = profileCfg
+	}
+
+	if err := config.ApplyDefaults(fs, q.cfg.Defaults); err != nil {
+		return nil, err
+	}
+	if err := checkChoice("sort", q.sort, config.SortValues); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// load разбирает запрос и загружает записи. При ошибке ответ уже
+// отправлен и возвращается nil.
+func (d *dashboard) load(w http.ResponseWriter, r *http.Request) (*apiQuery, []parser.LogEntry) {
+	q, err := d.query(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return nil, nil
+	}
+
+	sel, err := readSelection(q.cfg, q.profile, q.rng)
+	if err != nil {
+		var notFound *monthNotFoundError
+		status := http.StatusInternalServerError
+		if errors.As(err, &notFound) || errors.Is(err, errNoFiles) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return nil, nil
+	}
+
+	return q, normalizeEntries(sel.entries, newNormalizer(q.cfg))
+}
+
+func (d *dashboard) handleStats(w http.ResponseWriter, r *http.Request) {
+	q, entries := d.load(w, r)
+	if q == nil {
+		return
+	}
+
+	calculator := stats.NewCalculator(entries)
+	calculator.SetFilter(q.filter)
+	calculator.SetMinCount(q.minCount)
+
+	output, err := stats.FormatJSON(calculator.Calculate(q.sort, q.limit))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	io.WriteString(w, output)
+}
+
+func (d *dashboard) handleDays(w http.ResponseWriter, r *http.Request) {
+	q, entries := d.load(w, r)
+	if q == nil {
+		return
+	}
+
+	items := []dayJSON{}
+	for _, day := range stats.Daily(filterEntries(entries, q.filter)) {
+		items = append(items, dayJSON{
+			Date:  day.Date.Format("2006-01-02"),
+			Kills: day.KillCount,
+			Exp:   day.TotalExp,
+		})
+	}
+	writeJSON(w, items)
+}
+
+func (d *dashboard) handleSessions(w http.ResponseWriter, r *http.Request) {
+	q, entries := d.load(w, r)
+	if q == nil {
+		return
+	}
+
+	items := []sessionJSON{}
+	for _, s := range stats.Sessions(filterEntries(entries, q.filter), q.gap) {
+		items = append(items, newSessionJSON(s))
+	}
+	writeJSON(w, items)
+}
+
+func (d *dashboard) handleMonths(w http.ResponseWriter, r *http.Request) {
+	q, err := d.query(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	results, err := collectMonths(q.cfg, q.profile, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, monthItems(results))
+}
+
+// filterEntries оставляет записи, в имени монстра которых есть подстрока,
+// так же как Calculator.SetFilter.
+func filterEntries(entries []parser.LogEntry, filter string) []parser.LogEntry {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	if filter == "" {
+		return entries
+	}
+
+	var result []parser.LogEntry
+	for _, e := range entries {
+		if strings.Contains(strings.ToLower(e.MonsterName), filter) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(append(data, '\n'))
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	data, _ := json.Marshal(map[string]string{"error": err.Error()})
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(append(data, '\n'))
+}
@@ -0,0 +1,321 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"RQ_MobCounter/i18n"
+)
+
+type monsterResponse struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	Exp   int    `json:"exp"`
+}
+
+func get(t *testing.T, server *httptest.Server, path string) (int, string) {
+	t.Helper()
+	res, err := http.Get(server.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.StatusCode, string(body)
+}
+
+func newTestDashboard(t *testing.T, configPath string) *dashboard {
+	t.Helper()
+	cfg, err := loadConfig(configPath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newDashboard(cfg, "")
+}
+
+func TestDashboardAPI(t *testing.T) {
+	d := newTestDashboard(t, setup(t))
+	server := httptest.NewServer(d.handler())
+	defer server.Close()
+
+	status, body := get(t, server, "/")
+	if status != http.StatusOK || !strings.Contains(body, "/api/stats") {
+		t.Errorf("GET /: got %d %q", status, body)
+	}
+
+	status, body = get(t, server, "/api/stats?month=2026.01&sort=exp&limit=2")
+	var monsters []monsterResponse
+	if err := json.Unmarshal([]byte(body), &monsters); err != nil || status != http.StatusOK {
+		t.Fatalf("GET /api/stats: got %d %q", status, body)
+	}
+	if len(monsters) != 2 || monsters[0].Name != "Часы" || monsters[0].Count != 2 || monsters[0].Exp != 35060 {
+		t.Errorf("GET /api/stats: got %+v", monsters)
+	}
+
+	status, body = get(t, server, "/api/stats?all&filter=роси")
+	if err := json.Unmarshal([]byte(body), &monsters); err != nil || status != http.StatusOK {
+		t.Fatalf("GET /api/stats?all: got %d %q", status, body)
+	}
+	if len(monsters) != 1 || monsters[0].Name != "Росинка" {
+		t.Errorf("GET /api/stats?all&filter: got %+v", monsters)
+	}
+
+	status, body = get(t, server, "/api/days?month=2026.01")
+	var days []dayJSON
+	if err := json.Unmarshal([]byte(body), &days); err != nil || status != http.StatusOK {
+		t.Fatalf("GET /api/days: got %d %q", status, body)
+	}
+	if len(days) != 2 || days[0].Date != "2026-01-16" || days[0].Kills != 3 || days[1].Exp != 3 {
+		t.Errorf("GET /api/days: got %+v", days)
+	}
+
+	status, body = get(t, server, "/api/sessions?month=2026.01&gap=1h")
+	var sessions []sessionJSON
+	if err := json.Unmarshal([]byte(body), &sessions); err != nil || status != http.StatusOK {
+		t.Fatalf("GET /api/sessions: got %d %q", status, body)
+	}
+	if len(sessions) != 2 || sessions[0].Kills != 3 || sessions[0].TopMonster != "Часы" {
+		t.Errorf("GET /api/sessions: got %+v", sessions)
+	}
+
+	status, body = get(t, server, "/api/sessions?month=2026.01&gap=1h&filter=роси")
+	if err := json.Unmarshal([]byte(body), &sessions); err != nil || status != http.StatusOK {
+		t.Fatalf("GET /api/sessions?filter: got %d %q", status, body)
+	}
+	if len(sessions) != 1 || sessions[0].Kills != 1 || sessions[0].TopMonster != "Росинка" {
+		t.Errorf("GET /api/sessions?filter: got %+v", sessions)
+	}
+
+	status, body = get(t, server, "/api/months")
+	var months []monthJSON
+	if err := json.Unmarshal([]byte(body), &months); err != nil || status != http.StatusOK {
+		t.Fatalf("GET /api/months: got %d %q", status, body)
+	}
+	if len(months) != 1 || months[0].Month != "2026.01" || months[0].Kills != 4 {
+		t.Errorf("GET /api/months: got %+v", months)
+	}
+}
+
+func TestDashboardLanguage(t *testing.T) {
+	configPath := setup(t)
+	i18n.SetLanguage(i18n.English)
+	defer i18n.SetLanguage(i18n.Russian)
+
+	d := newTestDashboard(t, configPath)
+	server := httptest.NewServer(d.handler())
+	defer server.Close()
+
+	_, body := get(t, server, "/")
+	for _, want := range []string{`<html lang="en">`, "<label>Month ", `"allMonths":"All months"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("GET / in English: missing %q", want)
+		}
+	}
+	if strings.Contains(body, "Убийств") {
+		t.Errorf("GET / in English: found Russian labels")
+	}
+
+	currentLog(t, configPath)
+	_, body = get(t, server, "/overlay")
+	for _, want := range []string{`<html lang="en">`, `<span class="label">Kills</span>`, `<span class="label">Last</span>`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("GET /overlay in English: missing %q", want)
+		}
+	}
+}
+
+func TestDashboardAPIErrors(t *testing.T) {
+	d := newTestDashboard(t, setup(t))
+	server := httptest.NewServer(d.handler())
+	defer server.Close()
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/api/stats?month=2030.01", http.StatusNotFound},
+		{"/api/stats?month=2026.01&sort=size", http.StatusBadRequest},
+		{"/api/stats?month=2026.01&limit=ten", http.StatusBadRequest},
+		{"/api/sessions?month=2026.01&gap=soon", http.StatusBadRequest},
+		{"/api/stats?unknown=1", http.StatusBadRequest},
+		{"/api/stats?profile=nobody", http.StatusBadRequest},
+		{"/api/nothing", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		status, body := get(t, server, tt.path)
+		if status != tt.status {
+			t.Errorf("GET %s: got %d, want %d (%s)", tt.path, status, tt.status, body)
+		}
+		if strings.HasPrefix(tt.path, "/api/stats") && !strings.Contains(body, `"error"`) {
+			t.Errorf("GET %s: error body expected, got %q", tt.path, body)
+		}
+	}
+}
+
+// currentLog создаёт рядом с конфигом лог текущего месяца с одним
+// убийством и возвращает путь к нему.
+func currentLog(t *testing.T, configPath string) string {
+	t.Helper()
+	path := filepath.Join(filepath.Dir(configPath), "logs", fmt.Sprintf("exp (%s).htm", currentMonth()))
+	content := "<HTML><BODY><TABLE width=800>" + logRow(time.Now(), "Часы", 17530)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func logRow(at time.Time, monster string, exp int) string {
+	return fmt.Sprintf("<TR style='color:#4A92D3' valign=top title='%s'><TD colspan=2>%s погибает. Получено опыта: %d.\n",
+		at.Format("1/2 15:04:05"), monster, exp)
+}
+
+func TestOverlay(t *testing.T) {
+	configPath := setup(t)
+	currentLog(t, configPath)
+
+	d := newTestDashboard(t, configPath)
+	server := httptest.NewServer(d.handler())
+	defer server.Close()
+
+	status, body := get(t, server, "/overlay?gap=30m")
+	if status != http.StatusOK {
+		t.Fatalf("GET /overlay: got %d %q", status, body)
+	}
+	if !strings.Contains(body, `data-field="last_monster">Часы<`) || !strings.Contains(body, `/overlay/events?gap=30m`) {
+		t.Errorf("GET /overlay: unexpected page %q", body)
+	}
+
+	_, body = get(t, server, "/overlay/overlay.css")
+	if !strings.Contains(body, "background: transparent") {
+		t.Errorf("Default overlay.css: got %q", body)
+	}
+
+	// Файлы рядом с конфигом заменяют встроенные
+	dir := filepath.Dir(configPath)
+	if err := os.WriteFile(filepath.Join(dir, "overlay.css"), []byte("body { color: red; }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "overlay.html"), []byte("<b>{{.Kills}} / {{.Exp}}</b>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, body = get(t, server, "/overlay/overlay.css")
+	if body != "body { color: red; }" {
+		t.Errorf("Custom overlay.css: got %q", body)
+	}
+	_, body = get(t, server, "/overlay")
+	if body != "<b>1 / 17 530</b>" {
+		t.Errorf("Custom overlay.html: got %q", body)
+	}
+
+	if status, _ := get(t, server, "/overlay?profile=all"); status != http.StatusBadRequest {
+		t.Errorf("Overlay for all profiles: got %d, want 400", status)
+	}
+}
+
+func TestOverlayEvents(t *testing.T) {
+	configPath := setup(t)
+	logPath := currentLog(t, configPath)
+
+	d := newTestDashboard(t, configPath)
+	d.interval = 10 * time.Millisecond
+	server := httptest.NewServer(d.handler())
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/overlay/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type: got %q", ct)
+	}
+
+	reader := bufio.NewReader(res.Body)
+	next := func() overlayState {
+		t.Helper()
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("Reading events: %v", err)
+			}
+			if data, ok := strings.CutPrefix(line, "data: "); ok {
+				var state overlayState
+				if err := json.Unmarshal([]byte(data), &state); err != nil {
+					t.Fatal(err)
+				}
+				return state
+			}
+		}
+	}
+
+	if state := next(); state.Kills != "1" || state.LastMonster != "Часы" {
+		t.Errorf("Initial event: got %+v", state)
+	}
+
+	file, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(logRow(time.Now(), "Росинка", 3))
+	file.Close()
+
+	if state := next(); state.Kills != "2" || state.Exp != "17 533" || state.LastMonster != "Росинка" {
+		t.Errorf("Event after new row: got %+v", state)
+	}
+}
+
+func TestOverlayEventsSessionEnd(t *testing.T) {
+	configPath := setup(t)
+	currentLog(t, configPath)
+
+	d := newTestDashboard(t, configPath)
+	d.interval = 10 * time.Millisecond
+	server := httptest.NewServer(d.handler())
+	defer server.Close()
+
+	// Время в логе с точностью до секунды, поэтому gap с запасом
+	res, err := http.Get(server.URL + "/overlay/events?gap=2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var states []overlayState
+	reader := bufio.NewReader(res.Body)
+	for len(states) < 2 {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Reading events: %v", err)
+		}
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			var state overlayState
+			if err := json.Unmarshal([]byte(data), &state); err != nil {
+				t.Fatal(err)
+			}
+			states = append(states, state)
+		}
+	}
+
+	if states[0].Kills != "1" {
+		t.Errorf("Initial event: got %+v", states[0])
+	}
+	if states[1].Kills != "0" || states[1].LastMonster != "" {
+		t.Errorf("Event after gap without new rows: got %+v", states[1])
+	}
+}
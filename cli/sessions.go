@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/stats"
+	"RQ_MobCounter/term"
+)
+
+var sessionsCommand = &Command{
+	Name:    "sessions",
+	Summary: i18n.N("игровые сессии: длительность, убийства и опыт в час"),
+	Usage:   i18n.N("rqmc sessions [--gap 15m] [--month YYYY.MM | --all] [флаги]"),
+}
+
+func init() {
+	sessionsCommand.Run = runSessions
+}
+
+type sessionJSON struct {
+	Start        string `json:"start"`
+	End          string `json:"end"`
+	Minutes      int    `json:"minutes"`
+	Kills        int    `json:"kills"`
+	Exp          int    `json:"exp"`
+	ExpPerHour   int    `json:"exp_per_hour"`
+	KillsPerHour int    `json:"kills_per_hour"`
+	TopMonster   string `json:"top_monster"`
+}
+
+func runSessions(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, sessionsCommand)
+	common := addCommonFlags(fs, "table", "json")
+	rng := addRangeFlags(fs)
+	gap := fs.Duration("gap", stats.DefaultSessionGap, i18n.T("перерыв, после которого начинается новая сессия"))
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.setup(fs, common)
+	if err != nil {
+		return err
+	}
+
+	sel, err := ctx.loadSelection(cfg, common.profile, rng)
+	if err != nil || sel == nil {
+		return err
+	}
+
+	sessions := stats.Sessions(normalizeEntries(sel.entries, newNormalizer(cfg)), *gap)
+
+	if common.format == "json" {
+		items := make([]sessionJSON, 0, len(sessions))
+		for _, s := range sessions {
+			items = append(items, newSessionJSON(s))
+		}
+
+		data, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return i18n.Errorf("ошибка формирования JSON: %w", err)
+		}
+		ctx.printf("%s\n", data)
+		return nil
+	}
+
+	ctx.printf("%s", stats.FormatSessionTable(sessions))
+	ctx.printf("\n%s\n", ctx.paint(term.Green, i18n.T("Всего сессий: %d", len(sessions))))
+	return nil
+}
+
+func newSessionJSON(s stats.Session) sessionJSON {
+	return sessionJSON{
+		Start:        s.Start.Format("2006-01-02T15:04:05"),
+		End:          s.End.Format("2006-01-02T15:04:05"),
+		Minutes:      int(s.Duration().Minutes()),
+		Kills:        s.KillCount,
+		Exp:          s.TotalExp,
+		ExpPerHour:   s.ExpPerHour(),
+		KillsPerHour: s.KillsPerHour(),
+		TopMonster:   s.TopMonster,
+	}
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/stats"
+	"RQ_MobCounter/term"
+)
+
+var statsCommand = &Command{
+	Name:    "stats",
+	Summary: i18n.N("статистика убийств по монстрам (команда по умолчанию)"),
+	Usage:   i18n.N("rqmc stats [--exp] [--month YYYY.MM | --all] [флаги]"),
+}
+
+func init() {
+	statsCommand.Run = runStats
+}
+
+func runStats(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, statsCommand)
+	common := addCommonFlags(fs, config.FormatValues...)
+	rng := addRangeFlags(fs)
+	showExp := fs.Bool("exp", false, i18n.T("показывать опыт"))
+	sortBy := fs.String("sort", "count", i18n.T("сортировка: count (по количеству), exp (по опыту), avg (по среднему опыту) или name (по имени)"))
+	limit := fs.Int("limit", 20, i18n.T("максимальное количество записей для отображения (0 - без ограничений)"))
+	columns := fs.String("columns", "", i18n.T("колонки через запятую: name, count, exp, avg (по умолчанию name,count и exp при --exp)"))
+	filter := fs.String("filter", "", i18n.T("показывать только монстров, в имени которых есть подстрока"))
+	minCount := fs.Int("min-count", 0, i18n.T("показывать только монстров, убитых не меньше N раз"))
+	showVariants := fs.Bool("variants", false, i18n.T("показывать исходные имена, объединённые в одно"))
+	themeName := fs.String("theme", stats.DefaultTheme, i18n.T("цветовая тема таблицы: default, top (выделить первые 10), exp (выделить опыт) или none"))
+	chart := fs.String("chart", "", i18n.T("полоса рядом с каждой строкой: count (по количеству) или exp (по опыту)"))
+	sparkline := fs.Bool("sparkline", false, i18n.T("колонка с убийствами по дням за выбранный период"))
+	ascii := fs.Bool("ascii", false, i18n.T("рисовать графики символами ASCII (по умолчанию - если консоль не поддерживает Unicode)"))
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.setup(fs, common)
+	if err != nil {
+		return err
+	}
+
+	if err := checkChoice("sort", *sortBy, config.SortValues); err != nil {
+		return err
+	}
+	if err := checkChoice("theme", *themeName, stats.ThemeNames()); err != nil {
+		return err
+	}
+	if *chart != "" {
+		if err := checkChoice("chart", *chart, stats.ChartValues); err != nil {
+			return err
+		}
+	}
+	tableColumns, err := parseColumns(*columns)
+	if err != nil {
+		return err
+	}
+
+	sel, err := ctx.loadSelection(cfg, common.profile, rng)
+	if err != nil || sel == nil {
+		return err
+	}
+	allEntries := sel.entries
+
+	calculator := stats.NewCalculator(allEntries)
+	calculator.SetNormalizer(newNormalizer(cfg).Name)
+	calculator.SetFilter(*filter)
+	calculator.SetMinCount(*minCount)
+	monsterStats := calculator.Calculate(*sortBy, *limit)
+
+	switch common.format {
+	case "json":
+		output, err := stats.FormatJSON(monsterStats)
+		if err != nil {
+			return i18n.Errorf("ошибка формирования JSON: %w", err)
+		}
+		ctx.printf("%s", output)
+		return nil
+	case "csv":
+		output, err := stats.FormatCSV(monsterStats, tableColumns)
+		if err != nil {
+			return i18n.Errorf("ошибка формирования CSV: %w", err)
+		}
+		ctx.printf("%s", output)
+		return nil
+	}
+
+	if sel.files > 1 {
+		ctx.println(ctx.paint(term.Yellow, i18n.T("=== ОБЩАЯ СТАТИСТИКА ===")))
+		ctx.println()
+	}
+
+	opts := stats.TableOptions{
+		ShowExp:      *showExp,
+		ShowVariants: *showVariants,
+		Columns:      tableColumns,
+		Chart:        *chart,
+		Width:        term.Width(ctx.Stdout),
+		ASCII:        *ascii || !term.Unicode(),
+	}
+	if *sparkline {
+		opts.Sparklines = calculator.KillsPerDay()
+	}
+	if ctx.color {
+		theme := stats.Themes[*themeName]
+		opts.Theme = &theme
+	}
+	ctx.printf("%s", stats.FormatTableWithOptions(monsterStats, opts))
+
+	if len(sel.characters) > 0 {
+		ctx.printf("\n%s\n\n", ctx.paint(term.Yellow, i18n.T("=== ПО ПЕРСОНАЖАМ ===")))
+		ctx.printf("%s", stats.FormatCharacterTable(stats.ByCharacter(allEntries)))
+	}
+
+	ctx.printf("\n%s\n", ctx.paint(term.Green, i18n.T("Всего записей: %d", len(allEntries))))
+	if rng.dedupe {
+		ctx.println(ctx.paint(term.Green, i18n.T("Удалено дубликатов: %d", sel.duplicates)))
+	}
+	totalExp := 0
+	for _, m := range monsterStats {
+		totalExp += m.TotalExp
+	}
+	if *showExp {
+		ctx.println(ctx.paint(term.Green, i18n.T("Всего опыта: %s", stats.FormatNumberForDisplay(totalExp))))
+	}
+
+	return nil
+}
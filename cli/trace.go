@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/stats"
+	"RQ_MobCounter/term"
+)
+
+var traceCommand = &Command{
+	Name:    "trace",
+	Summary: i18n.N("все убийства монстра с указанием файла, строки и исходного текста"),
+	Usage:   i18n.N("rqmc trace --monster \"Имя\" [--month YYYY.MM | --all] [флаги]"),
+}
+
+func init() {
+	traceCommand.Run = runTrace
+}
+
+func runTrace(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, traceCommand)
+	common := addCommonFlags(fs)
+	rng := addRangeFlags(fs)
+	monster := fs.String("monster", "", i18n.T("имя монстра (сравнивается после нормализации)"))
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *monster == "" {
+		fmt.Fprintln(ctx.Stderr, i18n.T("укажите монстра: rqmc trace --monster \"Имя\""))
+		fs.Usage()
+		return errUsage
+	}
+
+	cfg, err := ctx.setup(fs, common)
+	if err != nil {
+		return err
+	}
+
+	sel, err := ctx.loadSelection(cfg, common.profile, rng)
+	if err != nil || sel == nil {
+		return err
+	}
+
+	normalizer := newNormalizer(cfg)
+	target := strings.ToLower(normalizer.Name(*monster))
+
+	found := 0
+	totalExp := 0
+	for _, entry := range sel.entries {
+		if strings.ToLower(normalizer.Name(entry.MonsterName)) != target {
+			continue
+		}
+
+		found++
+		totalExp += entry.ExpGained
+
+		character := ""
+		if entry.Character != "" {
+			character = "[" + entry.Character + "]  "
+		}
+		ctx.printf("%s  %s%s  +%s\n", ctx.paint(term.Yellow, entry.Timestamp), character,
+			entry.MonsterName, stats.FormatNumberForDisplay(entry.ExpGained))
+		ctx.printf("    %s:%d (смещение %d)\n", entry.Source, entry.Line, entry.Offset)
+		ctx.printf("    %s\n", strings.TrimSpace(entry.Raw))
+	}
+
+	if found == 0 {
+		return i18n.Errorf("убийства монстра %q не найдены", *monster)
+	}
+
+	ctx.printf("\n%s\n", ctx.paint(term.Green, i18n.T("Найдено убийств: %d", found)))
+	ctx.println(ctx.paint(term.Green, i18n.T("Всего опыта: %s", stats.FormatNumberForDisplay(totalExp))))
+	return nil
+}
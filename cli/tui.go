@@ -0,0 +1,277 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/live"
+	"RQ_MobCounter/logfiles"
+	"RQ_MobCounter/normalize"
+	"RQ_MobCounter/parser"
+	"RQ_MobCounter/term"
+	"RQ_MobCounter/tui"
+)
+
+var tuiCommand = &Command{
+	Name:    "tui",
+	Summary: i18n.N("полноэкранный просмотр: таблица монстров с сортировкой, фильтром и подробностями"),
+	Usage:   i18n.N("rqmc tui [--month YYYY.MM] [--sort count] [--interval 2s] [флаги]"),
+}
+
+func init() {
+	tuiCommand.Run = runTUI
+}
+
+// Управляющие последовательности полноэкранного режима: отдельный экран,
+// чтобы после выхода в консоли остался прежний вывод, и скрытый курсор.
+const (
+	enterScreen = "\033[?1049h\033[?25l" + clearScreen
+	leaveScreen = "\033[?25h\033[?1049l"
+	clearScreen = "\033[2J"
+)
+
+// resizeInterval - как часто проверять размер консоли.
+const resizeInterval = 250 * time.Millisecond
+
+func runTUI(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, tuiCommand)
+	common := addCommonFlags(fs)
+	month := fs.String("month", "", i18n.T("месяц, открытый при запуске (YYYY.MM), по умолчанию последний"))
+	sortBy := fs.String("sort", "count", i18n.T("сортировка: count, exp, avg или name"))
+	interval := fs.Duration("interval", live.DefaultInterval, i18n.T("как часто проверять файл"))
+	ascii := fs.Bool("ascii", false, i18n.T("рисовать графики символами ASCII (по умолчанию - если консоль не поддерживает Unicode)"))
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if common.profile == config.AllProfiles {
+		return errors.New(i18n.T("tui работает с одним профилем, укажите --profile имя"))
+	}
+
+	cfg, err := ctx.setup(fs, common)
+	if err != nil {
+		return err
+	}
+	if err := checkChoice("sort", *sortBy, config.SortValues); err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	stdin, ok := ctx.Stdin.(*os.File)
+	if !ok || !term.IsTerminal(stdin) || !term.IsTerminal(ctx.Stdout) {
+		return errors.New(i18n.T("rqmc tui работает только в консоли"))
+	}
+
+	browser, err := newTUIBrowser(cfg, *sortBy)
+	if err != nil {
+		return err
+	}
+	if *month != "" && !browser.model.SelectMonth(*month) {
+		return i18n.Errorf("файл для месяца %s не найден", *month)
+	}
+	browser.model.SetASCII(*ascii || !term.Unicode())
+	browser.model.SetColor(ctx.color)
+	browser.poll()
+	browser.load()
+
+	restore, err := term.MakeRaw(stdin)
+	if err != nil {
+		return i18n.Errorf("не удалось перевести консоль в полноэкранный режим: %w", err)
+	}
+	defer restore()
+	term.EnableVirtualTerminal(ctx.Stdout)
+	io.WriteString(ctx.Stdout, enterScreen)
+	defer io.WriteString(ctx.Stdout, leaveScreen)
+
+	signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	keys := make(chan []byte)
+	go readKeys(stdin, keys)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	resize := time.NewTicker(resizeInterval)
+	defer resize.Stop()
+
+	width, height := term.Size(ctx.Stdout)
+	browser.model.Resize(width, height)
+
+	for {
+		drawScreen(ctx.Stdout, browser.model.View())
+
+		select {
+		case <-signalCtx.Done():
+			return nil
+		case data, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			for _, key := range tui.ParseKeys(data) {
+				switch browser.model.HandleKey(key) {
+				case tui.ActionQuit:
+					return nil
+				case tui.ActionLoad:
+					browser.load()
+				}
+			}
+		case <-ticker.C:
+			browser.poll()
+		case <-resize.C:
+			w, h := term.Size(ctx.Stdout)
+			if w == width && h == height {
+				continue
+			}
+			width, height = w, h
+			browser.model.Resize(width, height)
+			io.WriteString(ctx.Stdout, clearScreen)
+		}
+	}
+}
+
+// tuiBrowser загружает записи для модели: прошлые месяцы читаются с диска
+// один раз, записи текущего месяца накапливаются из live.Watcher.
+type tuiBrowser struct {
+	cfg        *config.Config
+	model      *tui.Model
+	normalizer *normalize.Normalizer
+	watcher    *live.Watcher
+	files      map[string]logfiles.File
+	cache      map[string][]parser.LogEntry
+	liveMonth  string
+}
+
+func newTUIBrowser(cfg *config.Config, sortBy string) (*tuiBrowser, error) {
+	b := &tuiBrowser{
+		cfg:        cfg,
+		normalizer: newNormalizer(cfg),
+		watcher:    live.New(cfg.LogPath, cfg.FilePrefix),
+		cache:      make(map[string][]parser.LogEntry),
+	}
+	months, skipped, err := b.discover()
+	if err != nil {
+		return nil, err
+	}
+	if len(months) == 0 {
+		return nil, errNoFiles
+	}
+	b.model = tui.New(months, sortBy)
+	b.warnSkipped(skipped)
+	return b, nil
+}
+
+// discover обновляет список файлов и возвращает найденные месяцы и ошибки
+// пропущенных архивов.
+func (b *tuiBrowser) discover() ([]string, []error, error) {
+	files, skipped, err := logfiles.Scan(b.cfg.LogPath, b.cfg.FilePrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	b.files = make(map[string]logfiles.File, len(files))
+	months := make([]string, 0, len(files))
+	for _, f := range files {
+		b.files[f.Month] = f
+		months = append(months, f.Month)
+	}
+	return months, skipped, nil
+}
+
+// warnSkipped показывает в строке состояния первый пропущенный архив:
+// вывод в stderr испортил бы экран.
+func (b *tuiBrowser) warnSkipped(skipped []error) {
+	if len(skipped) > 0 {
+		b.model.SetStatus(i18n.T("архив пропущен: %v", skipped[0]))
+	}
+}
+
+// load передаёт модели записи выбранного месяца.
+func (b *tuiBrowser) load() {
+	month := b.model.Month()
+	if entries, ok := b.cache[month]; ok {
+		b.model.SetEntries(entries)
+		return
+	}
+
+	entries, err := logfiles.Load(b.files[month])
+	if err != nil {
+		b.model.SetStatus(i18n.T("ошибка при парсинге %s: %v", b.files[month].Name(), err))
+		b.model.SetEntries(nil)
+		return
+	}
+	entries = normalizeEntries(entries, b.normalizer)
+	// Записи текущего месяца приходят из Watcher: прочитанные с диска
+	// сейчас он вернёт ещё раз, поэтому их не запоминаем
+	if month != b.liveMonth {
+		b.cache[month] = entries
+	}
+	b.model.SetEntries(entries)
+}
+
+// poll добавляет новые записи текущего месяца. Первый вызов возвращает все
+// записи файла; при появлении нового месяца список месяцев обновляется.
+func (b *tuiBrowser) poll() {
+	update, err := b.watcher.Poll()
+	if err != nil {
+		b.model.SetStatus(err.Error())
+		return
+	}
+	b.liveMonth = update.Month
+	b.model.SetLiveMonth(update.Month)
+
+	_, known := b.files[update.Month]
+	if update.Rollover || (len(update.Entries) > 0 && !known) {
+		months, skipped, err := b.discover()
+		if err != nil {
+			b.model.SetStatus(err.Error())
+			return
+		}
+		b.model.SetMonths(months)
+		b.warnSkipped(skipped)
+	}
+	if len(update.Entries) == 0 && !update.Reset {
+		return
+	}
+
+	// После перезаписи файла Watcher возвращает его целиком
+	entries := normalizeEntries(update.Entries, b.normalizer)
+	if update.Reset {
+		b.cache[update.Month] = entries
+	} else {
+		b.cache[update.Month] = append(b.cache[update.Month], entries...)
+	}
+	if update.Month == b.model.Month() {
+		b.model.SetEntries(b.cache[update.Month])
+	}
+}
+
+// readKeys передаёт прочитанные из консоли байты в keys и закрывает канал,
+// когда ввод заканчивается.
+func readKeys(r io.Reader, keys chan<- []byte) {
+	buf := make([]byte, 256)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			keys <- append([]byte(nil), buf[:n]...)
+		}
+		if err != nil {
+			close(keys)
+			return
+		}
+	}
+}
+
+// drawScreen перерисовывает экран с левого верхнего угла. Строки модели уже
+// дополнены до ширины консоли, поэтому очищать экран не нужно.
+func drawScreen(w io.Writer, lines []string) {
+	io.WriteString(w, "\033[H"+strings.Join(lines, "\r\n"))
+}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/goals"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/live"
+	"RQ_MobCounter/parser"
+	"RQ_MobCounter/stats"
+	"RQ_MobCounter/term"
+)
+
+var watchCommand = &Command{
+	Name:    "watch",
+	Summary: i18n.N("следить за логом текущего месяца и выводить новые убийства"),
+	Usage:   i18n.N("rqmc watch [--interval 2s] [--gap 15m] [флаги]"),
+}
+
+func init() {
+	watchCommand.Run = runWatch
+}
+
+func runWatch(ctx *Context, args []string) error {
+	fs := newFlagSet(ctx, watchCommand)
+	common := addCommonFlags(fs)
+	interval := fs.Duration("interval", live.DefaultInterval, i18n.T("как часто проверять файл"))
+	gap := fs.Duration("gap", stats.DefaultSessionGap, i18n.T("перерыв, после которого начинается новая сессия"))
+	notifyEnabled := fs.Bool("notify", true, i18n.T("отправлять уведомления на вебхуки из секции notify конфига"))
+	hooksEnabled := fs.Bool("hooks", true, i18n.T("запускать команды из секции hooks конфига"))
+	goalsEnabled := fs.Bool("goals", true, i18n.T("сообщать о выполнении целей из rqmc goal"))
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if common.profile == config.AllProfiles {
+		return errors.New(i18n.T("watch работает с одним профилем, укажите --profile имя"))
+	}
+
+	cfg, err := ctx.setup(fs, common)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	normalizer := newNormalizer(cfg)
+	watcher := live.New(cfg.LogPath, cfg.FilePrefix)
+
+	var notifier *watchNotifier
+	if *notifyEnabled {
+		notifier = newWatchNotifier(cfg.Notify, normalizer, ctx.Stderr)
+	}
+	var runner *watchHooks
+	if *hooksEnabled {
+		runner = newWatchHooks(cfg.Hooks, *gap, normalizer, ctx.Stderr)
+	}
+	defer runner.wait()
+
+	var goalTracker *goals.Tracker
+	if *goalsEnabled {
+		if goalTracker, err = newGoalTracker(cfg, common.profile, normalizer); err != nil {
+			fmt.Fprintln(ctx.Stderr, i18n.T("цели не загружены: %v", err))
+		}
+	}
+
+	var entries []parser.LogEntry
+
+	printSession := func() {
+		sessions := stats.Sessions(entries, *gap)
+		if len(sessions) == 0 {
+			return
+		}
+		s := sessions[len(sessions)-1]
+		ctx.println(ctx.paint(term.Green, i18n.T("Сессия %s: убийств %d, опыт %s, опыт/час %s",
+			stats.FormatDuration(s.Duration()), s.KillCount,
+			stats.FormatNumberForDisplay(s.TotalExp), stats.FormatNumberForDisplay(s.ExpPerHour()))))
+	}
+
+	onError := func(err error) {
+		fmt.Fprintln(ctx.Stderr, i18n.T("ошибка чтения лога: %v", err))
+	}
+
+	// Уже записанная история не выводится построчно, а только учитывается
+	// в текущей сессии
+	update, err := watcher.Poll()
+	if err != nil {
+		onError(err)
+	}
+	entries = normalizeEntries(update.Entries, normalizer)
+	notifier.seed(entries)
+	runner.seed(entries, time.Now())
+
+	ctx.printf("Слежение за %s (Ctrl+C для выхода)\n", cfg.LogPath)
+	if notifier != nil {
+		ctx.printf("Уведомления: вебхуков %d\n", len(cfg.Notify.Webhooks))
+	}
+	if runner != nil {
+		ctx.printf("Команды: %d\n", len(cfg.Hooks.Commands))
+	}
+	printSession()
+
+	signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	notifier.start(signalCtx)
+	runner.start(signalCtx)
+
+	watcher.Run(signalCtx, *interval, func(update live.Update) {
+		if update.Rollover {
+			entries = nil
+			ctx.println(ctx.paint(term.Yellow, i18n.T("=== Новый месяц: %s ===", update.Month)))
+		}
+		if update.Reset {
+			entries = nil
+		}
+
+		for _, e := range update.Entries {
+			if e.MonsterName == "" {
+				continue
+			}
+			ctx.printf("%s  %s  +%s\n", ctx.paint(term.Yellow, e.Timestamp),
+				normalizer.Name(e.MonsterName), stats.FormatNumberForDisplay(e.ExpGained))
+		}
+		for _, l := range update.Levels {
+			ctx.printf("%s  %s\n", ctx.paint(term.Yellow, l.Timestamp), ctx.paint(term.Green, i18n.T("Достигнут %d уровень", l.Level)))
+		}
+
+		added := normalizeEntries(update.Entries, normalizer)
+		entries = append(entries, added...)
+		notifier.observe(update, added)
+		runner.observe(update, added, time.Now())
+		if goalTracker != nil {
+			for _, g := range goalTracker.Observe(added) {
+				e := goalEvent(g, time.Now())
+				ctx.println(ctx.paint(term.Green, e.Text))
+				notifier.send(e)
+			}
+		}
+		printSession()
+	}, onError)
+
+	return nil
+}
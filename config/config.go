@@ -5,73 +5,265 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+
+	"RQ_MobCounter/i18n"
 )
 
 type Config struct {
-	LogPath    string `json:"log_path"`
-	FilePrefix string `json:"file_prefix"`
+	Version    int                `json:"version"`
+	LogPath    string             `json:"log_path"`
+	FilePrefix string             `json:"file_prefix"`
+	Language   string             `json:"language,omitempty"`
+	Normalize  NormalizeConfig    `json:"normalize"`
+	Defaults   Defaults           `json:"defaults"`
+	Notify     NotifyConfig       `json:"notify,omitempty"`
+	Hooks      HooksConfig        `json:"hooks,omitempty"`
+	Profiles   map[string]Profile `json:"profiles,omitempty"`
+
+	path     string
+	migrated bool
+}
+
+type NormalizeConfig struct {
+	Prefixes []string          `json:"prefixes,omitempty"`
+	Suffixes []string          `json:"suffixes,omitempty"`
+	Aliases  map[string]string `json:"aliases,omitempty"`
+}
+
+type Candidate struct {
+	Path   string
+	Source string
 }
 
 const DefaultLogPath = `D:\B.A.S.E\Games\Royal Quest\chatlogs`
 const DefaultFilePrefix = "exp"
 
+const (
+	EnvConfig     = "RQMC_CONFIG"
+	EnvLogPath    = "RQMC_LOG_PATH"
+	EnvFilePrefix = "RQMC_FILE_PREFIX"
+	EnvDedupe     = "RQMC_DEDUPE"
+)
+
+// FileName - имя файла конфига во всех местах поиска.
+const FileName = "config.json"
+
 func Load() (*Config, error) {
-	exePath, err := os.Executable()
+	return LoadFrom("")
+}
+
+// LoadFrom ищет конфиг по цепочке: явно указанный путь (флаг --config),
+// переменная RQMC_CONFIG, ./config.json, пользовательская папка настроек,
+// папка приложения. Используется первый найденный файл, после чего
+// отдельные поля переопределяются переменными окружения. Конфиг старой
+// версии переводится в текущую только в памяти, файл не меняется.
+func LoadFrom(explicit string) (*Config, error) {
+	configPath, err := Locate(explicit)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := newConfig()
+	if configPath != "" {
+		if cfg, err = ReadFile(configPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, i18n.Errorf("некорректный конфиг: %w", err)
+	}
+
+	if configPath == "" && os.Getenv(EnvLogPath) == "" {
+		fmt.Fprintf(os.Stderr, "%s\n\n", i18n.T("⚠️ Предупреждение: файл config.json не найден!"))
+		fmt.Fprintln(os.Stderr, i18n.T("Создайте файл config.json в одном из мест:"))
+		for _, c := range Candidates("") {
+			fmt.Fprintf(os.Stderr, "  - %s\n", c.Path)
+		}
+		fmt.Fprintf(os.Stderr, "\n%s\n", i18n.T("Со следующим содержимым:"))
+		fmt.Fprintf(os.Stderr, "{\n")
+		fmt.Fprintf(os.Stderr, "  \"version\": %d,\n", CurrentVersion)
+		fmt.Fprintf(os.Stderr, "  \"log_path\": \"D:\\\\B.A.S.E\\\\Games\\\\Royal Quest\\\\chatlogs\",\n")
+		fmt.Fprintf(os.Stderr, "  \"file_prefix\": \"exp\"\n")
+		fmt.Fprintf(os.Stderr, "}\n\n")
+		fmt.Fprintln(os.Stderr, i18n.T("Использую значения по умолчанию."))
+		fmt.Fprintf(os.Stderr, "LogPath: %s\n", cfg.LogPath)
+		fmt.Fprintf(os.Stderr, "FilePrefix: %s\n\n", cfg.FilePrefix)
+	}
+
+	cfg.LogPath = filepath.FromSlash(cfg.LogPath)
+
+	return cfg, nil
+}
+
+// ReadFile читает конфиг из файла без переопределений из переменных
+// окружения, например чтобы сохранить его обратно после изменений.
+func ReadFile(configPath string) (*Config, error) {
+	data, err := os.ReadFile(configPath)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка определения пути приложения: %w", err)
+		return nil, i18n.Errorf("ошибка чтения конфига: %w", err)
 	}
 
-	exeDir := filepath.Dir(exePath)
-	configPath := filepath.Join(exeDir, "config.json")
+	cfg := newConfig()
+	migrated, err := decode(data, cfg)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка парсинга конфига %s: %w", configPath, err)
+	}
+	cfg.path = configPath
+	cfg.migrated = migrated
+
+	return cfg, nil
+}
 
-	cfg := &Config{
+func newConfig() *Config {
+	return &Config{
+		Version:    CurrentVersion,
 		LogPath:    DefaultLogPath,
 		FilePrefix: DefaultFilePrefix,
 	}
+}
 
-	if _, err := os.Stat(configPath); err == nil {
-		data, err := os.ReadFile(configPath)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка чтения конфига: %w", err)
+// Migrated сообщает, что файл конфига записан в старой версии схемы и
+// переведён в текущую только в памяти. Обновить файл можно через Save.
+func (c *Config) Migrated() bool {
+	return c.migrated
+}
+
+// Path возвращает путь к файлу, из которого был загружен конфиг, или пустую
+// строку, если используются значения по умолчанию.
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Candidates возвращает места поиска конфига в порядке приоритета.
+func Candidates(explicit string) []Candidate {
+	var candidates []Candidate
+
+	if explicit != "" {
+		candidates = append(candidates, Candidate{Path: explicit, Source: "--config"})
+	}
+	if env := os.Getenv(EnvConfig); env != "" {
+		candidates = append(candidates, Candidate{Path: env, Source: EnvConfig})
+	}
+	if wd, err := os.Getwd(); err == nil {
+		candidates = append(candidates, Candidate{Path: filepath.Join(wd, FileName), Source: i18n.T("рабочая папка")})
+	}
+	if path, err := UserPath(); err == nil {
+		candidates = append(candidates, Candidate{Path: path, Source: i18n.T("папка настроек пользователя")})
+	}
+	if dir, err := executableDir(); err == nil {
+		candidates = append(candidates, Candidate{Path: filepath.Join(dir, FileName), Source: i18n.T("папка приложения")})
+	}
+
+	return candidates
+}
+
+// Locate возвращает путь к первому найденному конфигу из Candidates или
+// пустую строку, если ни одного нет.
+func Locate(explicit string) (string, error) {
+	for _, c := range Candidates(explicit) {
+		_, err := os.Stat(c.Path)
+		if err == nil {
+			return c.Path, nil
 		}
 
-		if err := json.Unmarshal(data, cfg); err != nil {
-			return nil, fmt.Errorf("ошибка парсинга конфига: %w", err)
+		// Явно указанный файл обязан существовать
+		if c.Source == "--config" || c.Source == EnvConfig {
+			return "", i18n.Errorf("файл конфига %s (%s) не найден: %w", c.Path, c.Source, err)
 		}
-	} else {
-		fmt.Printf("⚠️ Предупреждение: файл config.json не найден в папке приложения!\n\n")
-		fmt.Printf("Создайте файл config.json со следующим содержимым:\n")
-		fmt.Printf("{\n")
-		fmt.Printf("  \"log_path\": \"D:\\\\B.A.S.E\\\\Games\\\\Royal Quest\\\\chatlogs\",\n")
-		fmt.Printf("  \"file_prefix\": \"exp\"\n")
-		fmt.Printf("}\n\n")
-		fmt.Printf("Использую значения по умолчанию.\n")
-		fmt.Printf("LogPath: %s\n", cfg.LogPath)
-		fmt.Printf("FilePrefix: %s\n\n", cfg.FilePrefix)
 	}
 
-	cfg.LogPath = filepath.FromSlash(cfg.LogPath)
+	return "", nil
+}
 
-	return cfg, nil
+func (c *Config) applyEnv() error {
+	if v := os.Getenv(EnvLogPath); v != "" {
+		c.LogPath = v
+	}
+	if v := os.Getenv(EnvFilePrefix); v != "" {
+		c.FilePrefix = v
+	}
+	if v := os.Getenv(EnvDedupe); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return i18n.Errorf("некорректное значение %s=%q: %w", EnvDedupe, v, err)
+		}
+		c.Defaults.Dedupe = &b
+	}
+	return nil
 }
 
-func (c *Config) Save() error {
+func executableDir() (string, error) {
 	exePath, err := os.Executable()
 	if err != nil {
-		return fmt.Errorf("ошибка определения пути приложения: %w", err)
+		return "", i18n.Errorf("ошибка определения пути приложения: %w", err)
 	}
 
-	exeDir := filepath.Dir(exePath)
-	configPath := filepath.Join(exeDir, "config.json")
+	return filepath.Dir(exePath), nil
+}
+
+// Dir возвращает папку, из которой загружен конфиг, или, как и Save,
+// папку настроек пользователя. Рядом с конфигом хранятся и другие файлы,
+// например goals.json.
+func (c *Config) Dir() (string, error) {
+	if c.path != "" {
+		return filepath.Dir(c.path), nil
+	}
+	path, err := UserPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(path), nil
+}
+
+// Save сохраняет конфиг в файл, из которого он был загружен, или в папку
+// настроек пользователя, если конфиг был создан со значениями по умолчанию.
+// Папка приложения не подходит: при go run это временная папка.
+func (c *Config) Save() error {
+	configPath := c.path
+	if configPath == "" {
+		var err error
+		if configPath, err = UserPath(); err != nil {
+			return err
+		}
+	}
+
+	return c.SaveTo(configPath)
+}
+
+// UserPath возвращает путь к конфигу в папке настроек пользователя:
+// os.UserConfigDir()/rqmc/config.json.
+func UserPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", i18n.Errorf("ошибка определения папки настроек пользователя: %w", err)
+	}
+	return filepath.Join(dir, "rqmc", FileName), nil
+}
+
+func (c *Config) SaveTo(configPath string) error {
+	c.Version = CurrentVersion
 
 	data, err := json.MarshalIndent(c, "", "  ")
 	if err != nil {
-		return fmt.Errorf("ошибка сериализации конфига: %w", err)
+		return i18n.Errorf("ошибка сериализации конфига: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return i18n.Errorf("ошибка создания папки конфига: %w", err)
 	}
 
 	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("ошибка сохранения конфига: %w", err)
+		return i18n.Errorf("ошибка сохранения конфига: %w", err)
 	}
 
+	c.path = configPath
+	c.migrated = false
+
 	return nil
 }
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"encoding/json"
+	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -90,4 +94,549 @@ func contains(s, substr string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+
+// isolate убирает влияние окружения разработчика на поиск конфига
+func isolate(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "xdg"))
+	t.Setenv("HOME", filepath.Join(dir, "home"))
+	t.Setenv("APPDATA", filepath.Join(dir, "appdata"))
+	t.Setenv(EnvConfig, "")
+	t.Setenv(EnvLogPath, "")
+	t.Setenv(EnvFilePrefix, "")
+	t.Setenv(EnvDedupe, "")
+	return dir
+}
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+}
+
+func TestLoadLookupOrder(t *testing.T) {
+	dir := isolate(t)
+
+	userDir, err := os.UserConfigDir()
+	if err != nil {
+		t.Skipf("No user config dir: %v", err)
+	}
+
+	userPath := filepath.Join(userDir, "rqmc", "config.json")
+	writeConfig(t, userPath, `{"log_path": "user"}`)
+
+	cfg, err := LoadFrom("")
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	if cfg.LogPath != "user" || cfg.Path() != userPath {
+		t.Errorf("Expected user config, got log_path=%q path=%q", cfg.LogPath, cfg.Path())
+	}
+
+	wdPath := filepath.Join(dir, "config.json")
+	writeConfig(t, wdPath, `{"log_path": "wd"}`)
+
+	cfg, err = LoadFrom("")
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	if cfg.LogPath != "wd" {
+		t.Errorf("Working dir config should win over user config, got %q", cfg.LogPath)
+	}
+
+	envPath := filepath.Join(dir, "env.json")
+	writeConfig(t, envPath, `{"log_path": "env"}`)
+	t.Setenv(EnvConfig, envPath)
+
+	cfg, err = LoadFrom("")
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	if cfg.LogPath != "env" {
+		t.Errorf("RQMC_CONFIG should win over working dir, got %q", cfg.LogPath)
+	}
+
+	flagPath := filepath.Join(dir, "flag.json")
+	writeConfig(t, flagPath, `{"log_path": "flag"}`)
+
+	cfg, err = LoadFrom(flagPath)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	if cfg.LogPath != "flag" || cfg.Path() != flagPath {
+		t.Errorf("--config should win over everything, got log_path=%q path=%q", cfg.LogPath, cfg.Path())
+	}
+}
+
+func TestLoadExplicitMissing(t *testing.T) {
+	dir := isolate(t)
+
+	if _, err := LoadFrom(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("LoadFrom should fail when --config file is missing")
+	}
+
+	t.Setenv(EnvConfig, filepath.Join(dir, "missing.json"))
+	if _, err := LoadFrom(""); err == nil {
+		t.Errorf("LoadFrom should fail when RQMC_CONFIG file is missing")
+	}
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	dir := isolate(t)
+	writeConfig(t, filepath.Join(dir, "config.json"), `{"log_path": "file", "file_prefix": "exp"}`)
+
+	t.Setenv(EnvLogPath, "from-env")
+	t.Setenv(EnvFilePrefix, "battle")
+	t.Setenv(EnvDedupe, "true")
+
+	cfg, err := LoadFrom("")
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+
+	if cfg.LogPath != "from-env" {
+		t.Errorf("LogPath: got %q, want %q", cfg.LogPath, "from-env")
+	}
+	if cfg.FilePrefix != "battle" {
+		t.Errorf("FilePrefix: got %q, want %q", cfg.FilePrefix, "battle")
+	}
+	if cfg.Defaults.Dedupe == nil || !*cfg.Defaults.Dedupe {
+		t.Errorf("Dedupe should be enabled by env")
+	}
+
+	t.Setenv(EnvDedupe, "maybe")
+	if _, err := LoadFrom(""); err == nil {
+		t.Errorf("LoadFrom should reject invalid boolean in %s", EnvDedupe)
+	}
+}
+
+func TestSaveToAndReload(t *testing.T) {
+	dir := isolate(t)
+	path := filepath.Join(dir, "nested", "config.json")
+
+	dedupe := true
+	cfg := &Config{LogPath: "logs", FilePrefix: "exp", Defaults: Defaults{Dedupe: &dedupe}}
+	if err := cfg.SaveTo(path); err != nil {
+		t.Fatalf("SaveTo failed: %v", err)
+	}
+	if cfg.Path() != path {
+		t.Errorf("Path after save: got %q, want %q", cfg.Path(), path)
+	}
+
+	loaded, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	if loaded.LogPath != "logs" || loaded.Defaults.Dedupe == nil || !*loaded.Defaults.Dedupe {
+		t.Errorf("Reloaded config mismatch: %+v", loaded)
+	}
+}
+
+func TestLoadRejectsUnknownField(t *testing.T) {
+	dir := isolate(t)
+	path := filepath.Join(dir, "config.json")
+	writeConfig(t, path, `{"logpath": "D:\\Logs", "file_prefix": "exp"}`)
+
+	_, err := LoadFrom(path)
+	if err == nil {
+		t.Fatalf("LoadFrom should reject unknown field")
+	}
+	if !contains(err.Error(), `"log_path"`) {
+		t.Errorf("Error should suggest log_path, got: %v", err)
+	}
+}
+
+func TestLoadRejectsUnknownNestedField(t *testing.T) {
+	dir := isolate(t)
+	path := filepath.Join(dir, "config.json")
+	writeConfig(t, path, `{"version": 2, "log_path": "logs", "normalize": {"prefixs": ["Элитный"]}}`)
+
+	_, err := LoadFrom(path)
+	if err == nil {
+		t.Fatalf("LoadFrom should reject unknown nested field")
+	}
+	if !contains(err.Error(), `"prefixes"`) {
+		t.Errorf("Error should suggest prefixes, got: %v", err)
+	}
+}
+
+func TestLoadSyntaxErrorHint(t *testing.T) {
+	dir := isolate(t)
+	path := filepath.Join(dir, "config.json")
+	writeConfig(t, path, "{\n  \"log_path\": \"D:\\Games\\chatlogs\"\n}")
+
+	_, err := LoadFrom(path)
+	if err == nil {
+		t.Fatalf("LoadFrom should reject invalid escape")
+	}
+	if !contains(err.Error(), "строке 2") {
+		t.Errorf("Error should point to line 2, got: %v", err)
+	}
+}
+
+func TestLoadMigratesOldConfig(t *testing.T) {
+	dir := isolate(t)
+	path := filepath.Join(dir, "config.json")
+	writeConfig(t, path, `{"log_path": "logs", "file_prefix": "exp"}`)
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	if cfg.Version != CurrentVersion || !cfg.Migrated() {
+		t.Errorf("Version: got %d (migrated %v), want %d", cfg.Version, cfg.Migrated(), CurrentVersion)
+	}
+
+	// Загрузка не меняет файл: его обновляет только явное сохранение
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if contains(string(data), "version") {
+		t.Errorf("LoadFrom should not rewrite the config, got:\n%s", data)
+	}
+
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read migrated config: %v", err)
+	}
+	if !contains(string(data), fmt.Sprintf(`"version": %d`, CurrentVersion)) || cfg.Migrated() {
+		t.Errorf("Saved config should have the current version, got:\n%s", data)
+	}
+}
+
+func TestReadFileIgnoresEnv(t *testing.T) {
+	dir := isolate(t)
+	path := filepath.Join(dir, "config.json")
+	writeConfig(t, path, `{"version": 2, "log_path": "logs"}`)
+	t.Setenv(EnvLogPath, "elsewhere")
+	t.Setenv(EnvDedupe, "true")
+
+	cfg, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if cfg.LogPath != "logs" || cfg.Defaults.Dedupe != nil {
+		t.Errorf("ReadFile should ignore env overrides, got log_path %q, dedupe %v", cfg.LogPath, cfg.Defaults.Dedupe)
+	}
+}
+
+func TestSaveDefaultsToUserDir(t *testing.T) {
+	dir := isolate(t)
+
+	cfg := &Config{LogPath: "logs", FilePrefix: "exp"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	want, _ := UserPath()
+	if cfg.Path() != want || !strings.HasPrefix(want, dir) {
+		t.Errorf("Save path: got %q, want %q inside %q", cfg.Path(), want, dir)
+	}
+}
+
+func TestDirDefaultsToUserDir(t *testing.T) {
+	isolate(t)
+
+	// goals.json и levels.csv не должны попадать во временную папку go run
+	dir, err := (&Config{}).Dir()
+	want, _ := UserPath()
+	if err != nil || dir != filepath.Dir(want) {
+		t.Errorf("Dir without config file: got %q, %v, want %q", dir, err, filepath.Dir(want))
+	}
+}
+
+func TestLoadRejectsNewerVersion(t *testing.T) {
+	dir := isolate(t)
+	path := filepath.Join(dir, "config.json")
+	writeConfig(t, path, `{"version": 999, "log_path": "logs"}`)
+
+	if _, err := LoadFrom(path); err == nil {
+		t.Errorf("LoadFrom should reject config from a newer version")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	logDir := t.TempDir()
+	file := filepath.Join(logDir, "file.txt")
+	os.WriteFile(file, []byte("x"), 0644)
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"valid", Config{LogPath: logDir, FilePrefix: "exp"}, false},
+		{"empty prefix", Config{LogPath: logDir, FilePrefix: ""}, true},
+		{"prefix with spaces", Config{LogPath: logDir, FilePrefix: " exp"}, true},
+		{"prefix with separator", Config{LogPath: logDir, FilePrefix: "logs/exp"}, true},
+		{"prefix with extension", Config{LogPath: logDir, FilePrefix: "exp.htm"}, true},
+		{"missing log path", Config{LogPath: filepath.Join(logDir, "missing"), FilePrefix: "exp"}, true},
+		{"log path is file", Config{LogPath: file, FilePrefix: "exp"}, true},
+		{"discord webhook", Config{LogPath: logDir, FilePrefix: "exp", Notify: NotifyConfig{Webhooks: []Webhook{{URL: "https://discord.com/api/webhooks/1/x", Format: "discord"}}}}, false},
+		{"webhook without scheme", Config{LogPath: logDir, FilePrefix: "exp", Notify: NotifyConfig{Webhooks: []Webhook{{URL: "discord.com/api"}}}}, true},
+		{"telegram without chat", Config{LogPath: logDir, FilePrefix: "exp", Notify: NotifyConfig{Webhooks: []Webhook{{URL: "https://api.telegram.org/bot1/sendMessage", Format: "telegram"}}}}, true},
+		{"unknown event", Config{LogPath: logDir, FilePrefix: "exp", Notify: NotifyConfig{Webhooks: []Webhook{{URL: "http://localhost", Events: []string{"death"}}}}}, true},
+		{"zero milestone", Config{LogPath: logDir, FilePrefix: "exp", Notify: NotifyConfig{Milestones: []int{0}}}, true},
+		{"kill hook", Config{LogPath: logDir, FilePrefix: "exp", Hooks: HooksConfig{Timeout: "5s", Commands: []HookCommand{{Event: "kill", Monster: "Волк", Command: "echo"}}}}, false},
+		{"unknown hook event", Config{LogPath: logDir, FilePrefix: "exp", Hooks: HooksConfig{Commands: []HookCommand{{Event: "death", Command: "echo"}}}}, true},
+		{"monster on level hook", Config{LogPath: logDir, FilePrefix: "exp", Hooks: HooksConfig{Commands: []HookCommand{{Event: "level", Monster: "Волк", Command: "echo"}}}}, true},
+		{"empty hook command", Config{LogPath: logDir, FilePrefix: "exp", Hooks: HooksConfig{Commands: []HookCommand{{Event: "kill"}}}}, true},
+		{"bad hook timeout", Config{LogPath: logDir, FilePrefix: "exp", Hooks: HooksConfig{Commands: []HookCommand{{Event: "kill", Command: "echo", Timeout: "-1s"}}}}, true},
+		{"negative max_concurrent", Config{LogPath: logDir, FilePrefix: "exp", Hooks: HooksConfig{MaxConcurrent: -1}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSuggestField(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"logpath", "log_path"},
+		{"LogPath", "log_path"},
+		{"file-prefix", "file_prefix"},
+		{"dedup", "dedupe"},
+		{"something_else_entirely", ""},
+	}
+
+	for _, tt := range tests {
+		if result := suggestField(tt.input); result != tt.expected {
+			t.Errorf("suggestField(%q): got %q, want %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestLoadMigratesDedupeIntoDefaults(t *testing.T) {
+	dir := isolate(t)
+	path := filepath.Join(dir, "config.json")
+	writeConfig(t, path, `{"version": 1, "log_path": "logs", "dedupe": true}`)
+
+	cfg, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if cfg.Defaults.Dedupe == nil || !*cfg.Defaults.Dedupe {
+		t.Errorf("dedupe should be moved into defaults")
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read migrated config: %v", err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Migrated config is not valid JSON: %v", err)
+	}
+	if _, ok := raw["dedupe"]; ok {
+		t.Errorf("Top-level dedupe should be removed, got:\n%s", data)
+	}
+	defaults, _ := raw["defaults"].(map[string]any)
+	if defaults["dedupe"] != true {
+		t.Errorf("defaults.dedupe should be true, got:\n%s", data)
+	}
+}
+
+func TestApplyDefaultsPrecedence(t *testing.T) {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	showExp := fs.Bool("exp", false, "")
+	sortBy := fs.String("sort", "count", "")
+	limit := fs.Int("limit", 20, "")
+	format := fs.String("format", "table", "")
+
+	if err := fs.Parse([]string{"--sort=name"}); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	configExp := true
+	configLimit := 0
+	err := ApplyDefaults(fs, Defaults{
+		ShowExp: &configExp,
+		Sort:    "exp",
+		Limit:   &configLimit,
+		Color:   "never",
+	})
+	if err != nil {
+		t.Fatalf("ApplyDefaults failed: %v", err)
+	}
+
+	// Явный флаг важнее конфига
+	if *sortBy != "name" {
+		t.Errorf("Explicit flag should win: sort = %q", *sortBy)
+	}
+	// Конфиг важнее встроенного значения
+	if !*showExp {
+		t.Errorf("Config default should apply to exp")
+	}
+	if *limit != 0 {
+		t.Errorf("Config default should apply to limit, got %d", *limit)
+	}
+	// Без значения в конфиге остаётся встроенное значение
+	if *format != "table" {
+		t.Errorf("Built-in default should stay: format = %q", *format)
+	}
+}
+
+func TestApplyDefaultsCommandOnly(t *testing.T) {
+	// show_exp относится к stats: у goal --exp - цель по опыту
+	fs := flag.NewFlagSet("goal", flag.ContinueOnError)
+	target := fs.String("exp", "", "")
+	filter := fs.String("filter", "", "")
+	fs.Parse(nil)
+
+	showExp := true
+	if err := ApplyDefaults(fs, Defaults{ShowExp: &showExp, Filter: "Часы"}); err != nil {
+		t.Fatalf("ApplyDefaults failed: %v", err)
+	}
+	if *target != "" || *filter != "Часы" {
+		t.Errorf("goal flags: got exp %q, filter %q", *target, *filter)
+	}
+}
+
+func TestApplyDefaultsInvalidValue(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("min-count", 0, "")
+	fs.Parse(nil)
+
+	err := ApplyDefaults(fs, Defaults{Filter: "Часы"})
+	if err != nil {
+		t.Errorf("Defaults for missing flags should be ignored, got %v", err)
+	}
+}
+
+func TestDefaultsValidate(t *testing.T) {
+	negative := -1
+
+	tests := []struct {
+		name     string
+		defaults Defaults
+		wantErr  bool
+	}{
+		{"empty", Defaults{}, false},
+		{"valid", Defaults{Sort: "exp", Format: "csv", Columns: []string{"name", "avg"}, Color: "never"}, false},
+		{"bad sort", Defaults{Sort: "kills"}, true},
+		{"bad format", Defaults{Format: "xml"}, true},
+		{"bad column", Defaults{Columns: []string{"name", "level"}}, true},
+		{"bad color", Defaults{Color: "sometimes"}, true},
+		{"auto color and theme", Defaults{Color: "auto", Theme: "exp"}, false},
+		{"bad theme", Defaults{Theme: "rainbow"}, true},
+		{"negative limit", Defaults{Limit: &negative}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.defaults.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProfileResolution(t *testing.T) {
+	showExp := true
+	limit := 50
+	cfg := &Config{
+		LogPath:    "common",
+		FilePrefix: "exp",
+		Defaults:   Defaults{ShowExp: &showExp, Sort: "count"},
+		Profiles: map[string]Profile{
+			"Лин": {LogPath: "lin"},
+			"Тор": {LogPath: "tor", FilePrefix: "battle", Defaults: Defaults{Sort: "exp", Limit: &limit}},
+		},
+	}
+
+	lin, err := cfg.Profile("Лин")
+	if err != nil {
+		t.Fatalf("Profile failed: %v", err)
+	}
+	if lin.LogPath != "lin" || lin.FilePrefix != "exp" || lin.Defaults.Sort != "count" {
+		t.Errorf("Лин should inherit prefix and defaults, got %+v", lin)
+	}
+	if len(lin.Profiles) != 0 {
+		t.Errorf("Resolved profile should not contain nested profiles")
+	}
+
+	tor, err := cfg.Profile("Тор")
+	if err != nil {
+		t.Fatalf("Profile failed: %v", err)
+	}
+	if tor.FilePrefix != "battle" || tor.Defaults.Sort != "exp" || *tor.Defaults.Limit != 50 || !*tor.Defaults.ShowExp {
+		t.Errorf("Тор should override prefix, sort and limit and keep show_exp, got %+v", tor.Defaults)
+	}
+
+	if _, err := cfg.Profile("Нет"); err == nil {
+		t.Errorf("Profile should fail for unknown name")
+	}
+
+	names := cfg.ProfileNames()
+	if len(names) != 2 || names[0] != "Лин" || names[1] != "Тор" {
+		t.Errorf("ProfileNames: got %v", names)
+	}
+}
+
+func TestProfileValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		profiles map[string]Profile
+		wantErr  bool
+	}{
+		{"valid", map[string]Profile{"main": {LogPath: "logs"}}, false},
+		{"reserved name", map[string]Profile{"all": {LogPath: "logs"}}, true},
+		{"missing log path", map[string]Profile{"main": {}}, true},
+		{"bad prefix", map[string]Profile{"main": {LogPath: "logs", FilePrefix: "a/b"}}, true},
+		{"bad defaults", map[string]Profile{"main": {LogPath: "logs", Defaults: Defaults{Sort: "kills"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{LogPath: "logs", FilePrefix: "exp", Profiles: tt.profiles}
+			err := cfg.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProfileValidationReportsOnce(t *testing.T) {
+	cfg := &Config{
+		LogPath:    "logs",
+		FilePrefix: "exp",
+		Defaults:   Defaults{Sort: "kills"},
+		Profiles: map[string]Profile{
+			"Лин": {LogPath: "lin"},
+			"Тор": {LogPath: "tor"},
+		},
+	}
+
+	err := cfg.validate()
+	if err == nil {
+		t.Fatal("validate() should fail for bad defaults.sort")
+	}
+	if n := strings.Count(err.Error(), "defaults.sort"); n != 1 {
+		t.Errorf("validate(): got defaults.sort error %d times, want once: %v", n, err)
+	}
+}
@@ -0,0 +1,181 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"slices"
+	"strconv"
+	"strings"
+
+	"RQ_MobCounter/i18n"
+)
+
+// Defaults хранит значения флагов командной строки по умолчанию.
+// Порядок приоритета: явно указанный флаг, затем значение из Defaults,
+// затем встроенное значение флага. Пустые строки и nil означают
+// «не задано».
+type Defaults struct {
+	ShowExp  *bool    `json:"show_exp,omitempty"`
+	Sort     string   `json:"sort,omitempty"`
+	Limit    *int     `json:"limit,omitempty"`
+	Format   string   `json:"format,omitempty"`
+	Columns  []string `json:"columns,omitempty"`
+	Filter   string   `json:"filter,omitempty"`
+	MinCount *int     `json:"min_count,omitempty"`
+	Color    string   `json:"color,omitempty"`
+	Theme    string   `json:"theme,omitempty"`
+	Variants *bool    `json:"variants,omitempty"`
+	Dedupe   *bool    `json:"dedupe,omitempty"`
+}
+
+var (
+	SortValues   = []string{"count", "exp", "avg", "name"}
+	FormatValues = []string{"table", "json", "csv"}
+	ColumnValues = []string{"name", "count", "exp", "avg"}
+	ColorValues  = []string{"auto", "always", "never"}
+	ThemeValues  = []string{"default", "exp", "none", "top"}
+)
+
+// commandOnly - флаги из defaults, которые относятся к одной команде:
+// флаг с тем же именем у другой команды значит другое (--exp у goal - цель
+// по опыту, а не показ опыта). Остальные значения применяются ко всем
+// командам, у которых есть такой флаг.
+var commandOnly = map[string]string{
+	"exp": "stats",
+}
+
+// Values возвращает заданные значения для команды command в виде
+// флаг → строковое значение.
+func (d Defaults) Values(command string) map[string]string {
+	values := make(map[string]string)
+
+	if d.ShowExp != nil {
+		values["exp"] = strconv.FormatBool(*d.ShowExp)
+	}
+	if d.Sort != "" {
+		values["sort"] = d.Sort
+	}
+	if d.Limit != nil {
+		values["limit"] = strconv.Itoa(*d.Limit)
+	}
+	if d.Format != "" {
+		values["format"] = d.Format
+	}
+	if len(d.Columns) > 0 {
+		values["columns"] = strings.Join(d.Columns, ",")
+	}
+	if d.Filter != "" {
+		values["filter"] = d.Filter
+	}
+	if d.MinCount != nil {
+		values["min-count"] = strconv.Itoa(*d.MinCount)
+	}
+	if d.Color != "" {
+		values["color"] = d.Color
+	}
+	if d.Theme != "" {
+		values["theme"] = d.Theme
+	}
+	if d.Variants != nil {
+		values["variants"] = strconv.FormatBool(*d.Variants)
+	}
+	if d.Dedupe != nil {
+		values["dedupe"] = strconv.FormatBool(*d.Dedupe)
+	}
+
+	for name, only := range commandOnly {
+		if only != command {
+			delete(values, name)
+		}
+	}
+	return values
+}
+
+// Merge возвращает d, дополненные заданными полями over.
+func (d Defaults) Merge(over Defaults) Defaults {
+	if over.ShowExp != nil {
+		d.ShowExp = over.ShowExp
+	}
+	if over.Sort != "" {
+		d.Sort = over.Sort
+	}
+	if over.Limit != nil {
+		d.Limit = over.Limit
+	}
+	if over.Format != "" {
+		d.Format = over.Format
+	}
+	if len(over.Columns) > 0 {
+		d.Columns = over.Columns
+	}
+	if over.Filter != "" {
+		d.Filter = over.Filter
+	}
+	if over.MinCount != nil {
+		d.MinCount = over.MinCount
+	}
+	if over.Color != "" {
+		d.Color = over.Color
+	}
+	if over.Theme != "" {
+		d.Theme = over.Theme
+	}
+	if over.Variants != nil {
+		d.Variants = over.Variants
+	}
+	if over.Dedupe != nil {
+		d.Dedupe = over.Dedupe
+	}
+	return d
+}
+
+// ApplyDefaults устанавливает значения из d для флагов, которые есть в fs,
+// но не были указаны в командной строке. Имя fs - имя команды. Вызывается
+// после fs.Parse.
+func ApplyDefaults(fs *flag.FlagSet, d Defaults) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	for name, value := range d.Values(fs.Name()) {
+		if explicit[name] || fs.Lookup(name) == nil {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return i18n.Errorf("некорректное значение по умолчанию для --%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (d Defaults) validate() error {
+	var errs []error
+
+	if d.Sort != "" && !slices.Contains(SortValues, d.Sort) {
+		errs = append(errs, i18n.Errorf("defaults.sort: %q, допустимо: %s", d.Sort, strings.Join(SortValues, ", ")))
+	}
+	if d.Format != "" && !slices.Contains(FormatValues, d.Format) {
+		errs = append(errs, i18n.Errorf("defaults.format: %q, допустимо: %s", d.Format, strings.Join(FormatValues, ", ")))
+	}
+	if d.Color != "" && !slices.Contains(ColorValues, d.Color) {
+		errs = append(errs, i18n.Errorf("defaults.color: %q, допустимо: %s", d.Color, strings.Join(ColorValues, ", ")))
+	}
+	if d.Theme != "" && !slices.Contains(ThemeValues, d.Theme) {
+		errs = append(errs, i18n.Errorf("defaults.theme: %q, допустимо: %s", d.Theme, strings.Join(ThemeValues, ", ")))
+	}
+	for _, column := range d.Columns {
+		if !slices.Contains(ColumnValues, column) {
+			errs = append(errs, i18n.Errorf("defaults.columns: %q, допустимо: %s", column, strings.Join(ColumnValues, ", ")))
+		}
+	}
+	if d.Limit != nil && *d.Limit < 0 {
+		errs = append(errs, errors.New(i18n.T("defaults.limit не может быть отрицательным")))
+	}
+	if d.MinCount != nil && *d.MinCount < 0 {
+		errs = append(errs, errors.New(i18n.T("defaults.min_count не может быть отрицательным")))
+	}
+
+	return errors.Join(errs...)
+}
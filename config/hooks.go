@@ -0,0 +1,67 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/i18n"
+)
+
+// HooksConfig - внешние команды, запускаемые в режиме watch при событиях.
+type HooksConfig struct {
+	// Timeout - ограничение времени работы команды по умолчанию, например "30s".
+	Timeout       string        `json:"timeout,omitempty"`
+	MaxConcurrent int           `json:"max_concurrent,omitempty"`
+	Commands      []HookCommand `json:"commands,omitempty"`
+}
+
+type HookCommand struct {
+	Event   string `json:"event"`
+	Monster string `json:"monster,omitempty"`
+	Command string `json:"command"`
+	Timeout string `json:"timeout,omitempty"`
+}
+
+var HookEvents = []string{"kill", "level", "session_end", "rollover"}
+
+// HookTimeout разбирает строку таймаута; пустая строка означает 0.
+func HookTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, i18n.Errorf("%q не является положительной длительностью, например \"10s\"", value)
+	}
+	return d, nil
+}
+
+func (h HooksConfig) validate() error {
+	var errs []error
+
+	if _, err := HookTimeout(h.Timeout); err != nil {
+		errs = append(errs, i18n.Errorf("hooks.timeout: %w", err))
+	}
+	if h.MaxConcurrent < 0 {
+		errs = append(errs, errors.New(i18n.T("hooks.max_concurrent: значение не может быть отрицательным")))
+	}
+
+	for i, c := range h.Commands {
+		if !slices.Contains(HookEvents, c.Event) {
+			errs = append(errs, i18n.Errorf("hooks.commands[%d].event: %q, допустимо: %s", i, c.Event, strings.Join(HookEvents, ", ")))
+		}
+		if c.Monster != "" && c.Event != "kill" {
+			errs = append(errs, i18n.Errorf("hooks.commands[%d].monster: задаётся только для события kill", i))
+		}
+		if strings.TrimSpace(c.Command) == "" {
+			errs = append(errs, i18n.Errorf("hooks.commands[%d].command не может быть пустым", i))
+		}
+		if _, err := HookTimeout(c.Timeout); err != nil {
+			errs = append(errs, i18n.Errorf("hooks.commands[%d].timeout: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
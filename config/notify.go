@@ -0,0 +1,62 @@
+package config
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+
+	"RQ_MobCounter/i18n"
+)
+
+// NotifyConfig - уведомления на вебхуки в режиме watch.
+type NotifyConfig struct {
+	Webhooks   []Webhook `json:"webhooks,omitempty"`
+	Rare       []string  `json:"rare,omitempty"`
+	Milestones []int     `json:"milestones,omitempty"`
+	LevelUp    bool      `json:"level_up,omitempty"`
+	Hourly     bool      `json:"hourly,omitempty"`
+}
+
+type Webhook struct {
+	URL    string   `json:"url"`
+	Format string   `json:"format,omitempty"`
+	ChatID string   `json:"chat_id,omitempty"`
+	Events []string `json:"events,omitempty"`
+}
+
+var (
+	WebhookFormats = []string{"json", "discord", "telegram"}
+	NotifyEvents   = []string{"rare", "milestone", "level", "summary", "goal"}
+)
+
+func (n NotifyConfig) validate() error {
+	var errs []error
+
+	for i, w := range n.Webhooks {
+		u, err := url.Parse(w.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, i18n.Errorf("notify.webhooks[%d].url: %q не является адресом http или https", i, w.URL))
+		}
+		if w.Format != "" && !slices.Contains(WebhookFormats, w.Format) {
+			errs = append(errs, i18n.Errorf("notify.webhooks[%d].format: %q, допустимо: %s", i, w.Format, strings.Join(WebhookFormats, ", ")))
+		}
+		if w.Format == "telegram" && w.ChatID == "" {
+			errs = append(errs, i18n.Errorf("notify.webhooks[%d]: для формата telegram нужен chat_id", i))
+		}
+		for _, event := range w.Events {
+			if !slices.Contains(NotifyEvents, event) {
+				errs = append(errs, i18n.Errorf("notify.webhooks[%d].events: %q, допустимо: %s", i, event, strings.Join(NotifyEvents, ", ")))
+			}
+		}
+	}
+
+	for _, m := range n.Milestones {
+		if m <= 0 {
+			errs = append(errs, errors.New(i18n.T("notify.milestones: значения должны быть больше нуля")))
+			break
+		}
+	}
+
+	return errors.Join(errs...)
+}
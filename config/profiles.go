@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"RQ_MobCounter/i18n"
+)
+
+// AllProfiles - значение --profile, означающее «все профили сразу».
+const AllProfiles = "all"
+
+type Profile struct {
+	LogPath    string   `json:"log_path"`
+	FilePrefix string   `json:"file_prefix,omitempty"`
+	Defaults   Defaults `json:"defaults"`
+}
+
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile возвращает копию конфига с настройками профиля: путь к логам и
+// префикс берутся из профиля (если заданы), а его defaults дополняют общие.
+func (c *Config) Profile(name string) (*Config, error) {
+	p, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return nil, i18n.Errorf("профиль %q не найден: в конфиге нет секции profiles", name)
+		}
+		return nil, i18n.Errorf("профиль %q не найден, доступные: %s", name, strings.Join(c.ProfileNames(), ", "))
+	}
+
+	resolved := *c
+	resolved.Profiles = nil
+	if p.LogPath != "" {
+		resolved.LogPath = filepath.FromSlash(p.LogPath)
+	}
+	if p.FilePrefix != "" {
+		resolved.FilePrefix = p.FilePrefix
+	}
+	resolved.Defaults = c.Defaults.Merge(p.Defaults)
+
+	return &resolved, nil
+}
+
+// validateProfiles проверяет только то, что профили переопределяют: общие
+// поля уже проверены validateFields, и их ошибки не повторяются для
+// каждого профиля.
+func (c *Config) validateProfiles() error {
+	var errs []error
+
+	for _, name := range c.ProfileNames() {
+		p := c.Profiles[name]
+
+		if strings.TrimSpace(name) == "" || name == AllProfiles {
+			errs = append(errs, i18n.Errorf("недопустимое имя профиля %q", name))
+			continue
+		}
+
+		if p.LogPath == "" {
+			errs = append(errs, i18n.Errorf("profiles.%s: не указан log_path", name))
+		}
+		if p.FilePrefix != "" {
+			if err := validatePrefix(p.FilePrefix); err != nil {
+				errs = append(errs, fmt.Errorf("profiles.%s: %w", name, err))
+			}
+		}
+		if err := p.Defaults.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("profiles.%s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
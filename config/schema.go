@@ -0,0 +1,299 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"RQ_MobCounter/i18n"
+)
+
+const CurrentVersion = 2
+
+// migrations[i] переводит конфиг из версии i в версию i+1.
+var migrations = []func(map[string]any) error{
+	migrateV0,
+	migrateV1,
+}
+
+// migrateV0 - конфиги без поля version: log_path и file_prefix остаются
+// как есть, добавляется только номер версии.
+func migrateV0(raw map[string]any) error {
+	return nil
+}
+
+// migrateV1 переносит поле dedupe в секцию defaults, где теперь хранятся
+// значения всех флагов по умолчанию.
+func migrateV1(raw map[string]any) error {
+	value, ok := raw["dedupe"]
+	if !ok {
+		return nil
+	}
+	delete(raw, "dedupe")
+
+	defaults, _ := raw["defaults"].(map[string]any)
+	if defaults == nil {
+		defaults = make(map[string]any)
+	}
+	if _, exists := defaults["dedupe"]; !exists {
+		defaults["dedupe"] = value
+	}
+	raw["defaults"] = defaults
+
+	return nil
+}
+
+var unknownFieldRegex = regexp.MustCompile(`unknown field "([^"]+)"`)
+
+const invalidPrefixChars = `<>:"/\|?*()`
+
+// decode переводит JSON к текущей версии схемы и строго разбирает его в cfg.
+// Возвращает true, если конфиг был мигрирован.
+func decode(data []byte, cfg *Config) (bool, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return false, describeSyntaxError(data, err)
+	}
+	if raw == nil {
+		return false, errors.New(i18n.T("конфиг должен быть JSON объектом"))
+	}
+
+	version, err := readVersion(raw)
+	if err != nil {
+		return false, err
+	}
+	if version > CurrentVersion {
+		return false, i18n.Errorf("конфиг версии %d создан более новой версией приложения (поддерживается до %d)", version, CurrentVersion)
+	}
+
+	for v := version; v < CurrentVersion; v++ {
+		if err := migrations[v](raw); err != nil {
+			return false, i18n.Errorf("ошибка миграции конфига с версии %d: %w", v, err)
+		}
+	}
+	raw["version"] = CurrentVersion
+
+	migrated, err := json.Marshal(raw)
+	if err != nil {
+		return false, i18n.Errorf("ошибка миграции конфига: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(migrated))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cfg); err != nil {
+		return false, describeDecodeError(err)
+	}
+
+	return version < CurrentVersion, nil
+}
+
+func readVersion(raw map[string]any) (int, error) {
+	value, ok := raw["version"]
+	if !ok {
+		return 0, nil
+	}
+
+	number, ok := value.(float64)
+	if !ok || number < 0 || number != float64(int(number)) {
+		return 0, i18n.Errorf("поле version должно быть целым неотрицательным числом, получено %v", value)
+	}
+
+	return int(number), nil
+}
+
+func describeSyntaxError(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return err
+	}
+
+	line, col := 1, 1
+	for _, b := range data[:min(int(syntaxErr.Offset), len(data))] {
+		if b == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+
+	msg := i18n.T("синтаксическая ошибка в строке %d, позиция %d: %v", line, col, err)
+	if strings.Contains(err.Error(), "escape") {
+		msg += i18n.T(" (в путях Windows обратную косую черту нужно удваивать: \"D:\\\\Games\\\\...\")")
+	}
+	return errors.New(msg)
+}
+
+func describeDecodeError(err error) error {
+	matches := unknownFieldRegex.FindStringSubmatch(err.Error())
+	if matches == nil {
+		return err
+	}
+
+	field := matches[1]
+	if suggestion := suggestField(field); suggestion != "" {
+		return i18n.Errorf("неизвестное поле %q, возможно, имелось в виду %q", field, suggestion)
+	}
+	return i18n.Errorf("неизвестное поле %q, допустимые поля: %s", field, strings.Join(topLevelFields(), ", "))
+}
+
+func suggestField(field string) string {
+	best := ""
+	bestDistance := 0
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(field))
+
+	for _, known := range knownFields(reflect.TypeOf(Config{})) {
+		candidate := strings.ReplaceAll(known, "_", "")
+		d := levenshtein(normalized, candidate)
+		if best == "" || d < bestDistance {
+			best, bestDistance = known, d
+		}
+	}
+
+	if bestDistance > max(2, len(field)/3) {
+		return ""
+	}
+	return best
+}
+
+func topLevelFields() []string {
+	var names []string
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if name := jsonName(t.Field(i)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func knownFields(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := jsonName(field)
+		if name == "" {
+			continue
+		}
+		names = append(names, name)
+
+		ft := field.Type
+		for ft.Kind() == reflect.Pointer || ft.Kind() == reflect.Slice || ft.Kind() == reflect.Map {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct {
+			names = append(names, knownFields(ft)...)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func jsonName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// validate проверяет поля, не зависящие от файловой системы.
+func (c *Config) validate() error {
+	return errors.Join(c.validateFields(), c.validateProfiles())
+}
+
+func (c *Config) validateFields() error {
+	var errs []error
+
+	if err := validatePrefix(c.FilePrefix); err != nil {
+		errs = append(errs, err)
+	}
+
+	if strings.TrimSpace(c.LogPath) == "" {
+		errs = append(errs, errors.New(i18n.T("log_path не может быть пустым")))
+	}
+
+	if c.Language != "" {
+		if _, ok := i18n.Parse(c.Language); !ok {
+			errs = append(errs, i18n.Errorf("language: %q, допустимо: %s", c.Language, strings.Join(i18n.Languages, ", ")))
+		}
+	}
+
+	if err := c.Defaults.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.Notify.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.Hooks.validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func validatePrefix(prefix string) error {
+	switch {
+	case strings.TrimSpace(prefix) == "":
+		return errors.New(i18n.T("file_prefix не может быть пустым"))
+	case strings.TrimSpace(prefix) != prefix:
+		return i18n.Errorf("file_prefix %q не должен начинаться или заканчиваться пробелом", prefix)
+	case strings.ContainsAny(prefix, invalidPrefixChars):
+		return i18n.Errorf("file_prefix %q содержит недопустимые символы (%s)", prefix, invalidPrefixChars)
+	case strings.HasSuffix(strings.ToLower(prefix), ".htm"):
+		return i18n.Errorf("file_prefix %q должен быть только началом имени файла, например \"exp\"", prefix)
+	}
+	return nil
+}
+
+// Validate проверяет конфиг целиком, включая существование папки с логами.
+func (c *Config) Validate() error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(c.LogPath)
+	if err != nil {
+		return i18n.Errorf("путь к логам не найден: %s", c.LogPath)
+	}
+	if !info.IsDir() {
+		return i18n.Errorf("log_path должен указывать на папку, а не на файл: %s", c.LogPath)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package dedupe
+
+import "RQ_MobCounter/parser"
+
+// DefaultMinRun - минимальная длина повторяющейся последовательности.
+// Одиночные совпадения не считаются дубликатами: за одну секунду можно
+// убить несколько одинаковых монстров с одинаковым опытом.
+const DefaultMinRun = 3
+
+type key struct {
+	timestamp string
+	monster   string
+	exp       int
+}
+
+func keyOf(e parser.LogEntry) key {
+	return key{timestamp: e.Timestamp, monster: e.MonsterName, exp: e.ExpGained}
+}
+
+// Remove удаляет повторно встречающиеся последовательности записей длиной
+// не меньше minRun. Записи должны идти в порядке файлов, поэтому перекрытие
+// между файлами и повтор внутри одного файла обрабатываются одинаково.
+//
+// Внутри файла повтор засчитывается, только если исходная
+// последовательность закончилась до текущей секунды: шесть одинаковых
+// убийств в одну секунду - это массовая атака, а не три записи и их копия.
+func Remove(entries []parser.LogEntry, minRun int) ([]parser.LogEntry, int) {
+	if minRun < 1 {
+		minRun = DefaultMinRun
+	}
+
+	keys := make([]key, len(entries))
+	blocks := make([]int, len(entries))
+	for i, e := range entries {
+		keys[i] = keyOf(e)
+		blocks[i] = i
+		if i > 0 && e.Source == entries[i-1].Source && e.Timestamp == entries[i-1].Timestamp {
+			blocks[i] = blocks[i-1]
+		}
+	}
+
+	seen := make(map[key][]int)
+	result := make([]parser.LogEntry, 0, len(entries))
+	removed := 0
+
+	for i := 0; i < len(entries); {
+		run := 0
+		for _, j := range seen[keys[i]] {
+			limit := i
+			if entries[j].Source == entries[i].Source {
+				limit = blocks[i]
+			}
+			if l := matchLength(keys, j, i, limit); l > run {
+				run = l
+			}
+		}
+
+		if run >= minRun {
+			removed += run
+			i += run
+			continue
+		}
+
+		seen[keys[i]] = append(seen[keys[i]], i)
+		result = append(result, entries[i])
+		i++
+	}
+
+	return result, removed
+}
+
+// matchLength возвращает длину совпадающих последовательностей, начинающихся
+// с позиций j и i (j < i). Первая последовательность должна закончиться до
+// позиции limit (не больше i), чтобы они не перекрывались.
+func matchLength(keys []key, j, i, limit int) int {
+	l := 0
+	for i+l < len(keys) && j+l < limit && keys[i+l] == keys[j+l] {
+		l++
+	}
+	return l
+}
@@ -0,0 +1,108 @@
+package dedupe
+
+import (
+	"testing"
+
+	"RQ_MobCounter/parser"
+)
+
+func entriesFrom(names ...string) []parser.LogEntry {
+	var entries []parser.LogEntry
+	for i, name := range names {
+		entries = append(entries, parser.LogEntry{
+			Timestamp:   "1/16 06:45:" + string(rune('0'+i%10)),
+			MonsterName: name,
+			ExpGained:   100,
+		})
+	}
+	return entries
+}
+
+func TestRemoveOverlappingFiles(t *testing.T) {
+	first := entriesFrom("A", "B", "C", "D")
+	second := append(append([]parser.LogEntry{}, first[1:]...), parser.LogEntry{
+		Timestamp: "1/16 07:00:00", MonsterName: "E", ExpGained: 50,
+	})
+
+	all := append(append([]parser.LogEntry{}, first...), second...)
+	result, removed := Remove(all, DefaultMinRun)
+
+	if removed != 3 {
+		t.Errorf("Expected 3 duplicates removed, got %d", removed)
+	}
+	if len(result) != 5 {
+		t.Fatalf("Expected 5 entries left, got %d", len(result))
+	}
+	if result[4].MonsterName != "E" {
+		t.Errorf("Last entry should be E, got %q", result[4].MonsterName)
+	}
+}
+
+func TestRemoveRepeatedWithinFile(t *testing.T) {
+	block := entriesFrom("A", "B", "C")
+	all := append(append([]parser.LogEntry{}, block...), block...)
+
+	result, removed := Remove(all, DefaultMinRun)
+
+	if removed != 3 || len(result) != 3 {
+		t.Errorf("Expected 3 removed and 3 left, got %d removed and %d left", removed, len(result))
+	}
+}
+
+func TestRemoveKeepsShortRepeats(t *testing.T) {
+	// Два одинаковых убийства в одну секунду - это не дубликат
+	entries := []parser.LogEntry{
+		{Timestamp: "1/16 06:45:41", MonsterName: "Росинка", ExpGained: 3},
+		{Timestamp: "1/16 06:45:41", MonsterName: "Росинка", ExpGained: 3},
+		{Timestamp: "1/16 06:45:41", MonsterName: "Росинка", ExpGained: 3},
+		{Timestamp: "1/16 06:45:50", MonsterName: "Часы", ExpGained: 17530},
+	}
+
+	result, removed := Remove(entries, DefaultMinRun)
+
+	if removed != 0 || len(result) != 4 {
+		t.Errorf("Expected nothing removed, got %d removed and %d left", removed, len(result))
+	}
+}
+
+func TestRemoveKeepsSameSecondKills(t *testing.T) {
+	// Шесть убийств одной атакой: не три записи и их повтор
+	var entries []parser.LogEntry
+	for range 6 {
+		entries = append(entries, parser.LogEntry{Timestamp: "1/16 06:45:41", MonsterName: "Росинка", ExpGained: 3, Source: "a.txt"})
+	}
+
+	result, removed := Remove(entries, DefaultMinRun)
+	if removed != 0 || len(result) != 6 {
+		t.Errorf("Expected nothing removed, got %d removed and %d left", removed, len(result))
+	}
+
+	// Те же записи в следующем файле - перекрытие, а не новые убийства
+	copied := append([]parser.LogEntry{}, entries[3:]...)
+	for i := range copied {
+		copied[i].Source = "b.txt"
+	}
+	result, removed = Remove(append(entries, copied...), DefaultMinRun)
+	if removed != 3 || len(result) != 6 {
+		t.Errorf("Expected 3 removed and 6 left across files, got %d removed and %d left", removed, len(result))
+	}
+}
+
+func TestRemoveIdenticalFiles(t *testing.T) {
+	file := entriesFrom("A", "B", "C", "D", "E")
+	all := append(append(append([]parser.LogEntry{}, file...), file...), file...)
+
+	result, removed := Remove(all, DefaultMinRun)
+
+	if removed != 10 || len(result) != 5 {
+		t.Errorf("Expected 10 removed and 5 left, got %d removed and %d left", removed, len(result))
+	}
+}
+
+func TestRemoveEmpty(t *testing.T) {
+	result, removed := Remove(nil, 0)
+
+	if removed != 0 || len(result) != 0 {
+		t.Errorf("Expected empty result, got %d removed and %d left", removed, len(result))
+	}
+}
@@ -0,0 +1,297 @@
+package doctor
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"RQ_MobCounter/config"
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/logfiles"
+	"RQ_MobCounter/parser"
+)
+
+type Status int
+
+const (
+	Pass Status = iota
+	Warn
+	Fail
+)
+
+type Result struct {
+	Name    string
+	Status  Status
+	Message string
+	Fix     string
+}
+
+// StaleAfter - через сколько времени без изменений файл текущего месяца
+// считается устаревшим.
+const StaleAfter = 72 * time.Hour
+
+// mojibake - характерные последовательности, которые появляются, когда
+// русский текст в UTF-8 прочитан как Windows-1251 или Latin-1.
+var mojibake = []string{"Ð", "Ñ", "Ã", "Р°", "Рµ", "Рѕ", "�"}
+
+// Run выполняет все проверки: конфиг, затем логи основного конфига или
+// каждого профиля.
+func Run(explicit string, now time.Time) []Result {
+	cfg, result := CheckConfig(explicit)
+	results := []Result{result}
+	if cfg == nil {
+		return results
+	}
+
+	if len(cfg.Profiles) == 0 {
+		return append(results, CheckLogs(cfg, "", now)...)
+	}
+
+	for _, name := range cfg.ProfileNames() {
+		profileCfg, err := cfg.Profile(name)
+		if err != nil {
+			results = append(results, Result{Name: i18n.T("Профиль %s", name), Status: Fail, Message: err.Error()})
+			continue
+		}
+		results = append(results, CheckLogs(profileCfg, name, now)...)
+	}
+
+	return results
+}
+
+func CheckConfig(explicit string) (*config.Config, Result) {
+	result := Result{Name: i18n.T("Конфиг")}
+
+	cfg, err := config.LoadFrom(explicit)
+	if err != nil {
+		result.Status = Fail
+		result.Message = err.Error()
+		result.Fix = i18n.T("исправьте ошибку в конфиге или создайте его заново командой rqmc init")
+		return nil, result
+	}
+
+	if cfg.Path() == "" {
+		result.Status = Warn
+		result.Message = i18n.T("файл config.json не найден, используются значения по умолчанию")
+		result.Fix = i18n.T("создайте конфиг командой rqmc init")
+		return cfg, result
+	}
+
+	if cfg.Migrated() {
+		result.Status = Warn
+		result.Message = i18n.T("%s записан в старой версии схемы, настройки переводятся в версию %d при каждом запуске", cfg.Path(), cfg.Version)
+		result.Fix = i18n.T("обновите файл командой rqmc config migrate")
+		return cfg, result
+	}
+
+	result.Status = Pass
+	result.Message = i18n.T("%s (версия %d)", cfg.Path(), cfg.Version)
+	return cfg, result
+}
+
+// CheckLogs проверяет папку с логами и файлы логов. profile добавляется
+// к названиям проверок, если конфиг содержит несколько профилей.
+func CheckLogs(cfg *config.Config, profile string, now time.Time) []Result {
+	name := func(s string) string {
+		if profile == "" {
+			return s
+		}
+		return fmt.Sprintf("%s [%s]", s, profile)
+	}
+
+	dirResult := Result{Name: name(i18n.T("Папка с логами"))}
+	info, err := os.Stat(cfg.LogPath)
+	switch {
+	case err != nil:
+		dirResult.Status = Fail
+		dirResult.Message = i18n.T("%s не найдена", cfg.LogPath)
+		dirResult.Fix = i18n.T("укажите правильный log_path в конфиге или запустите rqmc init")
+		return []Result{dirResult}
+	case !info.IsDir():
+		dirResult.Status = Fail
+		dirResult.Message = i18n.T("%s - это файл, а не папка", cfg.LogPath)
+		dirResult.Fix = i18n.T("log_path должен указывать на папку chatlogs")
+		return []Result{dirResult}
+	}
+
+	if _, err := os.ReadDir(cfg.LogPath); err != nil {
+		dirResult.Status = Fail
+		dirResult.Message = i18n.T("нет доступа к %s: %v", cfg.LogPath, err)
+		dirResult.Fix = i18n.T("проверьте права доступа к папке")
+		return []Result{dirResult}
+	}
+
+	dirResult.Status = Pass
+	dirResult.Message = cfg.LogPath
+	results := []Result{dirResult}
+
+	prefixes, err := logfiles.Prefixes(cfg.LogPath)
+	if err != nil {
+		return append(results, Result{Name: name(i18n.T("Файлы логов")), Status: Fail, Message: err.Error()})
+	}
+
+	results = append(results, checkPrefix(cfg, prefixes, name(i18n.T("Префикс"))))
+
+	files, skipped, err := logfiles.Scan(cfg.LogPath, cfg.FilePrefix)
+	if err != nil {
+		return append(results, Result{Name: name(i18n.T("Файлы логов")), Status: Fail, Message: err.Error()})
+	}
+	for _, err := range skipped {
+		results = append(results, Result{
+			Name:    name(i18n.T("Архив")),
+			Status:  Warn,
+			Message: err.Error(),
+			Fix:     i18n.T("архив пропускается; проверьте, что он не повреждён"),
+		})
+	}
+	if len(files) == 0 {
+		return results
+	}
+
+	months := make([]string, 0, len(files))
+	for _, f := range files {
+		months = append(months, f.Month)
+	}
+	results = append(results, Result{
+		Name:    name(i18n.T("Месяцы")),
+		Status:  Pass,
+		Message: fmt.Sprintf("%d: %s", len(months), strings.Join(months, ", ")),
+	})
+
+	for _, f := range files {
+		results = append(results, checkFile(f, name(i18n.T("Файл %s", f.Month)))...)
+	}
+
+	return append(results, checkCurrentMonth(files, name(i18n.T("Текущий месяц")), now))
+}
+
+func checkPrefix(cfg *config.Config, prefixes []logfiles.PrefixInfo, name string) Result {
+	var found []string
+	for _, p := range prefixes {
+		found = append(found, i18n.T("%s (%d мес.)", p.Prefix, len(p.Months)))
+	}
+
+	if len(prefixes) == 0 {
+		return Result{
+			Name:    name,
+			Status:  Fail,
+			Message: i18n.T("в папке нет файлов вида «префикс (YYYY.MM).htm»"),
+			Fix:     i18n.T("включите в игре Настройки → Чат → ✓ Сохранять историю сообщений"),
+		}
+	}
+
+	if !slices.ContainsFunc(prefixes, func(p logfiles.PrefixInfo) bool { return p.Prefix == cfg.FilePrefix }) {
+		return Result{
+			Name:    name,
+			Status:  Fail,
+			Message: i18n.T("файлов с префиксом %q нет, найдены: %s", cfg.FilePrefix, strings.Join(found, ", ")),
+			Fix:     i18n.T("укажите \"file_prefix\": %q в конфиге", prefixes[0].Prefix),
+		}
+	}
+
+	return Result{Name: name, Status: Pass, Message: i18n.T("%s, найдены: %s", cfg.FilePrefix, strings.Join(found, ", "))}
+}
+
+func checkFile(f logfiles.File, name string) []Result {
+	rc, err := f.Open()
+	if err != nil {
+		return []Result{{Name: name, Status: Fail, Message: err.Error(), Fix: i18n.T("проверьте, что файл не повреждён")}}
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return []Result{{Name: name, Status: Fail, Message: err.Error(), Fix: i18n.T("проверьте, что файл не повреждён")}}
+	}
+
+	var results []Result
+
+	if problem := encodingProblem(data); problem != "" {
+		results = append(results, Result{
+			Name:    name,
+			Status:  Warn,
+			Message: fmt.Sprintf("%s: %s", f.Name(), problem),
+			Fix:     i18n.T("файл должен быть в UTF-8, не пересохраняйте его в другой кодировке"),
+		})
+	}
+
+	entries, err := parser.Parse(bytes.NewReader(data), f.Name())
+	if err != nil {
+		return append(results, Result{Name: name, Status: Fail, Message: err.Error()})
+	}
+
+	if len(entries) == 0 {
+		return append(results, Result{
+			Name:    name,
+			Status:  Warn,
+			Message: i18n.T("%s: не найдено ни одного убийства", f.Name()),
+			Fix:     i18n.T("убедитесь, что в этот чат попадают сообщения «... погибает. Получено опыта: N»"),
+		})
+	}
+
+	if len(results) == 0 {
+		results = append(results, Result{Name: name, Status: Pass, Message: i18n.T("убийств: %d", len(entries))})
+	}
+	return results
+}
+
+func encodingProblem(data []byte) string {
+	if !utf8.Valid(data) {
+		return i18n.T("файл не в кодировке UTF-8")
+	}
+
+	text := string(data)
+	for _, pattern := range mojibake {
+		if strings.Contains(text, pattern) {
+			return i18n.T("похоже на испорченную кодировку (встречается %q)", pattern)
+		}
+	}
+
+	return ""
+}
+
+func checkCurrentMonth(files []logfiles.File, name string, now time.Time) Result {
+	month := fmt.Sprintf("%d.%02d", now.Year(), now.Month())
+
+	var current *logfiles.File
+	for i := range files {
+		if files[i].Month == month {
+			current = &files[i]
+		}
+	}
+
+	if current == nil {
+		return Result{
+			Name:    name,
+			Status:  Warn,
+			Message: i18n.T("файла за %s нет", month),
+			Fix:     i18n.T("если вы играли в этом месяце, игра не сохраняет историю: проверьте настройку чата и попробуйте полноэкранный режим"),
+		}
+	}
+
+	if current.Kind != logfiles.Plain {
+		return Result{Name: name, Status: Pass, Message: i18n.T("%s (архив)", current.Name())}
+	}
+
+	info, err := os.Stat(current.Path)
+	if err != nil {
+		return Result{Name: name, Status: Fail, Message: err.Error()}
+	}
+
+	age := now.Sub(info.ModTime())
+	if age > StaleAfter {
+		return Result{
+			Name:    name,
+			Status:  Warn,
+			Message: i18n.T("файл не обновлялся %d дн. (последнее изменение %s)", int(age.Hours()/24), info.ModTime().Format("2006-01-02 15:04")),
+			Fix:     i18n.T("если вы играли за это время, игра перестала сохранять историю: проверьте настройку чата и попробуйте полноэкранный режим"),
+		}
+	}
+
+	return Result{Name: name, Status: Pass, Message: i18n.T("обновлён %s", info.ModTime().Format("2006-01-02 15:04"))}
+}
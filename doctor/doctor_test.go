@@ -0,0 +1,133 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"RQ_MobCounter/config"
+)
+
+const killRow = "<TR style='color:#4A92D3' valign=top title='1/16 06:45:41'><TD colspan=2>Часы погибает. Получено опыта: 17530.\n"
+
+func writeLog(t *testing.T, dir, name, content string, modTime time.Time) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set mtime: %v", err)
+	}
+}
+
+func findResult(results []Result, name string) (Result, bool) {
+	for _, r := range results {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Result{}, false
+}
+
+func TestCheckLogsHealthy(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 20, 12, 0, 0, 0, time.Local)
+	writeLog(t, dir, "exp (2025.12).htm", killRow, now.AddDate(0, 0, -25))
+	writeLog(t, dir, "exp (2026.01).htm", killRow, now.Add(-time.Hour))
+
+	results := CheckLogs(&config.Config{LogPath: dir, FilePrefix: "exp"}, "", now)
+
+	for _, r := range results {
+		if r.Status != Pass {
+			t.Errorf("Check %q should pass, got %d: %s", r.Name, r.Status, r.Message)
+		}
+	}
+
+	if r, ok := findResult(results, "Месяцы"); !ok || r.Message != "2: 2025.12, 2026.01" {
+		t.Errorf("Months result: %+v", r)
+	}
+}
+
+func TestCheckLogsMissingDir(t *testing.T) {
+	results := CheckLogs(&config.Config{LogPath: filepath.Join(t.TempDir(), "missing"), FilePrefix: "exp"}, "", time.Now())
+
+	if len(results) != 1 || results[0].Status != Fail || results[0].Fix == "" {
+		t.Errorf("Missing dir should fail with a fix, got %+v", results)
+	}
+}
+
+func TestCheckLogsWrongPrefix(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	writeLog(t, dir, "battle (2026.01).htm", killRow, now)
+
+	results := CheckLogs(&config.Config{LogPath: dir, FilePrefix: "exp"}, "", now)
+
+	r, ok := findResult(results, "Префикс")
+	if !ok || r.Status != Fail {
+		t.Fatalf("Prefix check should fail, got %+v", r)
+	}
+	if r.Fix != `укажите "file_prefix": "battle" в конфиге` {
+		t.Errorf("Fix should suggest detected prefix, got %q", r.Fix)
+	}
+}
+
+func TestCheckLogsProblems(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 20, 12, 0, 0, 0, time.Local)
+	writeLog(t, dir, "exp (2025.11).htm", "<HTML>Вы достигли 2 уровня!</HTML>", now.AddDate(0, -2, 0))
+	writeLog(t, dir, "exp (2025.12).htm", "Ð§Ð°ÑÑ‹ "+killRow, now.AddDate(0, -1, 0))
+	writeLog(t, dir, "exp (2026.01).htm", killRow, now.AddDate(0, 0, -5))
+	writeLog(t, dir, "old.zip", "not a zip", now)
+
+	results := CheckLogs(&config.Config{LogPath: dir, FilePrefix: "exp"}, "Лин", now)
+
+	if r, ok := findResult(results, "Архив [Лин]"); !ok || r.Status != Warn {
+		t.Errorf("Broken archive should warn, got %+v", r)
+	}
+	if r, ok := findResult(results, "Месяцы [Лин]"); !ok || r.Message != "3: 2025.11, 2025.12, 2026.01" {
+		t.Errorf("Broken archive should not hide months, got %+v", r)
+	}
+
+	if r, ok := findResult(results, "Файл 2025.11 [Лин]"); !ok || r.Status != Warn {
+		t.Errorf("File without kills should warn, got %+v", r)
+	}
+	if r, ok := findResult(results, "Файл 2025.12 [Лин]"); !ok || r.Status != Warn {
+		t.Errorf("File with broken encoding should warn, got %+v", r)
+	}
+	if r, ok := findResult(results, "Текущий месяц [Лин]"); !ok || r.Status != Warn {
+		t.Errorf("Stale current month should warn, got %+v", r)
+	}
+}
+
+func TestCheckCurrentMonthMissing(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.Local)
+	writeLog(t, dir, "exp (2026.01).htm", killRow, now)
+
+	results := CheckLogs(&config.Config{LogPath: dir, FilePrefix: "exp"}, "", now)
+
+	if r, ok := findResult(results, "Текущий месяц"); !ok || r.Status != Warn {
+		t.Errorf("Missing current month should warn, got %+v", r)
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	os.WriteFile(path, []byte(`{"version": 2, "logpath": "x"}`), 0644)
+
+	cfg, result := CheckConfig(path)
+	if cfg != nil || result.Status != Fail {
+		t.Errorf("Invalid config should fail, got %+v", result)
+	}
+
+	os.WriteFile(path, []byte(`{"version": 2, "log_path": "x", "file_prefix": "exp"}`), 0644)
+
+	cfg, result = CheckConfig(path)
+	if cfg == nil || result.Status != Pass {
+		t.Errorf("Valid config should pass, got %+v", result)
+	}
+}
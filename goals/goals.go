@@ -0,0 +1,119 @@
+package goals
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/parser"
+)
+
+// FileName - имя файла целей в папке конфига.
+const FileName = "goals.json"
+
+// Что считается в цели.
+const (
+	MetricKills = "kills"
+	MetricExp   = "exp"
+)
+
+var Metrics = []string{MetricKills, MetricExp}
+
+type Goal struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Profile string `json:"profile,omitempty"`
+	// Monster - подстрока имени монстра без учёта регистра, как во флаге
+	// --filter. Пусто - все монстры.
+	Monster string    `json:"monster,omitempty"`
+	Metric  string    `json:"metric"`
+	Target  int       `json:"target"`
+	Start   time.Time `json:"start"`
+}
+
+// Matches сообщает, относится ли запись к цели: убийство подходящего
+// монстра не раньше начала цели.
+func (g Goal) Matches(e parser.LogEntry) bool {
+	if e.MonsterName == "" || e.Time.Before(g.Start) {
+		return false
+	}
+	return g.Monster == "" || strings.Contains(strings.ToLower(e.MonsterName), strings.ToLower(g.Monster))
+}
+
+// Value возвращает вклад записи в цель: 1 или опыт для подходящих
+// убийств, иначе 0.
+func (g Goal) Value(e parser.LogEntry) int {
+	switch {
+	case !g.Matches(e):
+		return 0
+	case g.Metric == MetricExp:
+		return e.ExpGained
+	default:
+		return 1
+	}
+}
+
+// File - список целей, сохранённый в JSON.
+type File struct {
+	Goals []Goal `json:"goals"`
+
+	path string
+}
+
+// Load читает файл целей. Отсутствующий файл - пустой список.
+func Load(path string) (*File, error) {
+	f := &File{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, i18n.Errorf("ошибка разбора %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Add добавляет цель со следующим свободным номером и возвращает её.
+func (f *File) Add(g Goal) Goal {
+	g.ID = 1
+	for _, existing := range f.Goals {
+		g.ID = max(g.ID, existing.ID+1)
+	}
+	f.Goals = append(f.Goals, g)
+	return g
+}
+
+// Remove удаляет цель по номеру и сообщает, была ли она.
+func (f *File) Remove(id int) bool {
+	i := slices.IndexFunc(f.Goals, func(g Goal) bool { return g.ID == id })
+	if i < 0 {
+		return false
+	}
+	f.Goals = slices.Delete(f.Goals, i, i+1)
+	return true
+}
+
+func (f *File) Save() error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(f.path, append(data, '\n'), 0644)
+}
+
+func (f *File) Path() string {
+	return f.path
+}
@@ -0,0 +1,103 @@
+package goals
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"RQ_MobCounter/parser"
+)
+
+func kill(name string, exp int, minute int) parser.LogEntry {
+	return parser.LogEntry{MonsterName: name, ExpGained: exp, Time: at(minute)}
+}
+
+func at(minute int) time.Time {
+	return time.Date(2026, 1, 16, 10, 0, 0, 0, time.Local).Add(time.Duration(minute) * time.Minute)
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", FileName)
+
+	f, err := Load(path)
+	if err != nil || len(f.Goals) != 0 {
+		t.Fatalf("Missing file: got %+v, %v", f, err)
+	}
+
+	first := f.Add(Goal{Monster: "Часы", Metric: MetricKills, Target: 500, Start: at(0)})
+	second := f.Add(Goal{Metric: MetricExp, Target: 1000, Start: at(0)})
+	if first.ID != 1 || second.ID != 2 {
+		t.Errorf("IDs: got %d, %d", first.ID, second.ID)
+	}
+	if !f.Remove(1) || f.Remove(1) {
+		t.Errorf("Remove: goal 1 should be removed once")
+	}
+	if g := f.Add(Goal{Metric: MetricKills, Target: 1}); g.ID != 3 {
+		t.Errorf("ID after remove: got %d, want 3", g.ID)
+	}
+	if err := f.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Goals) != 2 || loaded.Goals[0].ID != 2 || loaded.Goals[0].Target != 1000 || !loaded.Goals[0].Start.Equal(at(0)) {
+		t.Errorf("Loaded: got %+v", loaded.Goals)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	entries := []parser.LogEntry{
+		kill("Часы", 100, -10), // до начала цели
+		kill("Злая шкатулка", 50, 0),
+		kill("Часы", 100, 0),
+		kill("Часы", 100, 30),
+		kill("Часы", 100, 60),
+	}
+
+	tests := []struct {
+		goal     Goal
+		current  int
+		rate     int
+		eta      time.Duration
+		complete bool
+	}{
+		{Goal{Monster: "часы", Metric: MetricKills, Target: 9, Start: at(0)}, 3, 3, 2 * time.Hour, false},
+		{Goal{Metric: MetricExp, Target: 700, Start: at(0)}, 350, 350, time.Hour, false},
+		{Goal{Monster: "Часы", Metric: MetricKills, Target: 2, Start: at(0)}, 3, 3, 0, true},
+		{Goal{Monster: "Росинка", Metric: MetricKills, Target: 2, Start: at(0)}, 0, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		p := Compute(tt.goal, entries, 45*time.Minute)
+		if p.Current != tt.current || p.Rate != tt.rate || p.ETA != tt.eta || p.Complete() != tt.complete {
+			t.Errorf("%+v: got current %d, rate %d, eta %s, complete %v", tt.goal, p.Current, p.Rate, p.ETA, p.Complete())
+		}
+	}
+
+	p := Compute(Goal{Monster: "Часы", Metric: MetricKills, Target: 2, Start: at(0)}, entries, 15*time.Minute)
+	if !p.CompletedAt.Equal(at(30)) || p.Fraction() != 1 {
+		t.Errorf("Completed: got %s, fraction %v", p.CompletedAt, p.Fraction())
+	}
+}
+
+func TestTracker(t *testing.T) {
+	list := []Goal{
+		{ID: 1, Monster: "Часы", Metric: MetricKills, Target: 2, Start: at(0)},
+		{ID: 2, Metric: MetricExp, Target: 100, Start: at(0)},
+	}
+	tracker := NewTracker(list, []parser.LogEntry{kill("Часы", 10, 0)})
+
+	if done := tracker.Observe([]parser.LogEntry{kill("Росинка", 10, 1)}); len(done) != 0 {
+		t.Errorf("Not yet: got %+v", done)
+	}
+	done := tracker.Observe([]parser.LogEntry{kill("Часы", 80, 2)})
+	if len(done) != 2 || done[0].ID != 1 || done[1].ID != 2 {
+		t.Errorf("Completed: got %+v", done)
+	}
+	if done := tracker.Observe([]parser.LogEntry{kill("Часы", 80, 3)}); len(done) != 0 {
+		t.Errorf("Already completed: got %+v", done)
+	}
+}
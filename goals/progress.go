@@ -0,0 +1,107 @@
+package goals
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"RQ_MobCounter/parser"
+	"RQ_MobCounter/stats"
+)
+
+type Progress struct {
+	Goal
+	Current int
+	// CompletedAt - время записи, с которой цель выполнена.
+	CompletedAt time.Time
+	// Rate - скорость в час за последнюю игровую сессию, 0 - неизвестна.
+	Rate int
+	// ETA - сколько ещё играть при текущей скорости, 0 - неизвестно или
+	// цель уже выполнена.
+	ETA time.Duration
+}
+
+func (p Progress) Complete() bool {
+	return p.Current >= p.Target
+}
+
+// Fraction возвращает долю выполнения от 0 до 1.
+func (p Progress) Fraction() float64 {
+	if p.Target <= 0 {
+		return 1
+	}
+	return min(float64(p.Current)/float64(p.Target), 1)
+}
+
+// Compute считает прогресс цели по записям. Скорость берётся из последней
+// сессии подходящих убийств (сессии разбиваются по перерывам длиннее gap).
+func Compute(g Goal, entries []parser.LogEntry, gap time.Duration) Progress {
+	var matching []parser.LogEntry
+	for _, e := range entries {
+		if !e.Time.IsZero() && g.Matches(e) {
+			matching = append(matching, e)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		return matching[i].Time.Before(matching[j].Time)
+	})
+
+	p := Progress{Goal: g}
+	for _, e := range matching {
+		p.Current += g.Value(e)
+		if p.CompletedAt.IsZero() && p.Complete() {
+			p.CompletedAt = e.Time
+		}
+	}
+
+	sessions := stats.Sessions(matching, gap)
+	if len(sessions) == 0 {
+		return p
+	}
+	last := sessions[len(sessions)-1]
+	if g.Metric == MetricExp {
+		p.Rate = last.ExpPerHour()
+	} else {
+		p.Rate = last.KillsPerHour()
+	}
+	if p.Rate > 0 && !p.Complete() {
+		p.ETA = time.Duration(float64(p.Target-p.Current) / float64(p.Rate) * float64(time.Hour))
+	}
+	return p
+}
+
+// Tracker следит за выполнением целей в режиме watch.
+type Tracker struct {
+	goals   []Goal
+	current []int
+}
+
+// NewTracker учитывает уже записанные убийства, не сообщая о выполненных
+// до запуска целях.
+func NewTracker(goals []Goal, entries []parser.LogEntry) *Tracker {
+	t := &Tracker{goals: goals, current: make([]int, len(goals))}
+	t.add(entries)
+	return t
+}
+
+// Observe учитывает новые записи и возвращает цели, выполненные ими.
+func (t *Tracker) Observe(entries []parser.LogEntry) []Goal {
+	before := slices.Clone(t.current)
+	t.add(entries)
+
+	var completed []Goal
+	for i, g := range t.goals {
+		if before[i] < g.Target && t.current[i] >= g.Target {
+			completed = append(completed, g)
+		}
+	}
+	return completed
+}
+
+func (t *Tracker) add(entries []parser.LogEntry) {
+	for _, e := range entries {
+		for i, g := range t.goals {
+			t.current[i] += g.Value(e)
+		}
+	}
+}
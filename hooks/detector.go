@@ -0,0 +1,87 @@
+package hooks
+
+import (
+	"time"
+
+	"RQ_MobCounter/parser"
+	"RQ_MobCounter/stats"
+)
+
+// Detector превращает поток записей лога в события для команд. Конец
+// сессии определяется так же, как в stats.Sessions: по перерыву длиннее gap
+// после последнего убийства.
+type Detector struct {
+	gap     time.Duration
+	session *stats.Session
+}
+
+func NewDetector(gap time.Duration) *Detector {
+	return &Detector{gap: gap}
+}
+
+// Seed продолжает сессию, начатую до запуска, если она ещё не закончилась
+// к моменту now. Событий при этом не создаётся.
+func (d *Detector) Seed(entries []parser.LogEntry, now time.Time) {
+	sessions := stats.Sessions(entries, d.gap)
+	if len(sessions) == 0 {
+		return
+	}
+	last := sessions[len(sessions)-1]
+	if now.Sub(last.End) <= d.gap {
+		d.session = &last
+	}
+}
+
+// Observe возвращает события для новых записей и повышений уровня.
+func (d *Detector) Observe(entries []parser.LogEntry, levels []parser.LevelUp) []Event {
+	var events []Event
+
+	for _, e := range entries {
+		if e.MonsterName == "" || e.Time.IsZero() {
+			continue
+		}
+
+		if d.session != nil && e.Time.Sub(d.session.End) > d.gap {
+			events = append(events, d.end())
+		}
+		if d.session == nil {
+			d.session = &stats.Session{Start: e.Time}
+		}
+		d.session.End = e.Time
+		d.session.KillCount++
+		d.session.TotalExp += e.ExpGained
+
+		events = append(events, Event{Type: EventKill, Time: e.Time, Monster: e.MonsterName, Exp: e.ExpGained})
+	}
+
+	for _, l := range levels {
+		events = append(events, Event{Type: EventLevel, Time: l.Time, Level: l.Level})
+	}
+
+	return events
+}
+
+// Tick возвращает событие конца сессии, если с последнего убийства прошло
+// больше gap.
+func (d *Detector) Tick(now time.Time) []Event {
+	if d.session == nil || now.Sub(d.session.End) <= d.gap {
+		return nil
+	}
+	return []Event{d.end()}
+}
+
+func (d *Detector) end() Event {
+	s := d.session
+	d.session = nil
+	return Event{
+		Type: EventSessionEnd,
+		Time: s.End,
+		Session: &Session{
+			Start:      s.Start,
+			End:        s.End,
+			Kills:      s.KillCount,
+			Exp:        s.TotalExp,
+			ExpPerHour: s.ExpPerHour(),
+		},
+	}
+}
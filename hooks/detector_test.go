@@ -0,0 +1,69 @@
+package hooks
+
+import (
+	"testing"
+	"time"
+
+	"RQ_MobCounter/parser"
+)
+
+func kill(name string, exp int, minute int) parser.LogEntry {
+	return parser.LogEntry{MonsterName: name, ExpGained: exp, Time: at(minute)}
+}
+
+func at(minute int) time.Time {
+	return time.Date(2026, 1, 16, 10, 0, 0, 0, time.Local).Add(time.Duration(minute) * time.Minute)
+}
+
+func types(events []Event) []string {
+	var result []string
+	for _, e := range events {
+		result = append(result, e.Type)
+	}
+	return result
+}
+
+func TestDetectorObserve(t *testing.T) {
+	d := NewDetector(15 * time.Minute)
+
+	events := d.Observe([]parser.LogEntry{kill("Часы", 10, 0), {Raw: "без монстра"}}, []parser.LevelUp{{Level: 42, Time: at(1)}})
+	if got := types(events); len(got) != 2 || got[0] != EventKill || got[1] != EventLevel {
+		t.Fatalf("Kill and level: got %v", got)
+	}
+	if events[0].Monster != "Часы" || events[0].Exp != 10 || events[1].Level != 42 {
+		t.Errorf("Event fields: got %+v", events)
+	}
+
+	// Убийство после перерыва закрывает предыдущую сессию
+	events = d.Observe([]parser.LogEntry{kill("Часы", 20, 10), kill("Часы", 30, 60)}, nil)
+	if got := types(events); len(got) != 3 || got[1] != EventSessionEnd {
+		t.Fatalf("Session end by kill: got %v", got)
+	}
+	s := events[1].Session
+	if s.Kills != 2 || s.Exp != 30 || !s.Start.Equal(at(0)) || !s.End.Equal(at(10)) {
+		t.Errorf("Session: got %+v", s)
+	}
+}
+
+func TestDetectorTick(t *testing.T) {
+	d := NewDetector(15 * time.Minute)
+
+	// Сессия, закончившаяся до запуска, не продолжается
+	d.Seed([]parser.LogEntry{kill("Часы", 10, 0)}, at(30))
+	if events := d.Tick(at(60)); len(events) != 0 {
+		t.Errorf("Finished seed session: got %+v", events)
+	}
+
+	d.Seed([]parser.LogEntry{kill("Часы", 10, 0), kill("Часы", 10, 5)}, at(10))
+	if events := d.Tick(at(20)); len(events) != 0 {
+		t.Errorf("Within gap: got %+v", events)
+	}
+
+	events := d.Tick(at(21))
+	if len(events) != 1 || events[0].Type != EventSessionEnd || events[0].Session.Kills != 2 {
+		t.Fatalf("After gap: got %+v", events)
+	}
+	if events := d.Tick(at(40)); len(events) != 0 {
+		t.Errorf("Second tick: got %+v", events)
+	}
+}
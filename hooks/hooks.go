@@ -0,0 +1,223 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"RQ_MobCounter/i18n"
+)
+
+// Типы событий.
+const (
+	EventKill       = "kill"
+	EventLevel      = "level"
+	EventSessionEnd = "session_end"
+	EventRollover   = "rollover"
+)
+
+var Events = []string{EventKill, EventLevel, EventSessionEnd, EventRollover}
+
+const (
+	DefaultTimeout       = 30 * time.Second
+	DefaultMaxConcurrent = 2
+	DefaultQueueSize     = 100
+)
+
+// Event передаётся команде в stdin как JSON и в переменных окружения
+// RQMC_EVENT, RQMC_TIME, RQMC_MONSTER и т.д.
+type Event struct {
+	Type    string    `json:"event"`
+	Time    time.Time `json:"time"`
+	Monster string    `json:"monster,omitempty"`
+	Exp     int       `json:"exp,omitempty"`
+	Level   int       `json:"level,omitempty"`
+	Month   string    `json:"month,omitempty"`
+	Session *Session  `json:"session,omitempty"`
+}
+
+type Session struct {
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	Kills      int       `json:"kills"`
+	Exp        int       `json:"exp"`
+	ExpPerHour int       `json:"exp_per_hour"`
+}
+
+type Hook struct {
+	Event string
+	// Monster - для kill: имя монстра (без учёта регистра), пусто - любой.
+	Monster string
+	Command string
+	// Timeout - ограничение времени работы команды, 0 - DefaultTimeout.
+	Timeout time.Duration
+}
+
+func (h Hook) matches(e Event) bool {
+	if h.Event != e.Type {
+		return false
+	}
+	return h.Monster == "" || strings.EqualFold(h.Monster, e.Monster)
+}
+
+// Runner запускает команды для событий. Одновременно работает не больше
+// maxConcurrent команд, остальные ждут в очереди. Если очередь заполнена,
+// запуск пропускается с сообщением в log.
+type Runner struct {
+	hooks []Hook
+	queue chan job
+	log   io.Writer
+	mu    sync.Mutex
+	jobs  sync.WaitGroup
+}
+
+type job struct {
+	ctx   context.Context
+	hook  Hook
+	event Event
+}
+
+// New создаёт Runner. Результаты запусков пишутся в log.
+func New(hooks []Hook, maxConcurrent int, log io.Writer) *Runner {
+	return newRunner(hooks, maxConcurrent, DefaultQueueSize, log)
+}
+
+func newRunner(hooks []Hook, maxConcurrent, queueSize int, log io.Writer) *Runner {
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrent
+	}
+	r := &Runner{hooks: hooks, queue: make(chan job, queueSize), log: log}
+	for range maxConcurrent {
+		go r.work()
+	}
+	return r
+}
+
+// Fire ставит в очередь все команды, подходящие к событию, и не ждёт их
+// выполнения.
+func (r *Runner) Fire(ctx context.Context, e Event) {
+	for _, h := range r.hooks {
+		if !h.matches(e) {
+			continue
+		}
+		r.jobs.Add(1)
+		select {
+		case r.queue <- job{ctx: ctx, hook: h, event: e}:
+		default:
+			r.jobs.Done()
+			r.logln(i18n.T("очередь команд переполнена, пропущено: hook %s: %q", e.Type, h.Command))
+		}
+	}
+}
+
+// Wait ждёт завершения всех команд из очереди.
+func (r *Runner) Wait() {
+	r.jobs.Wait()
+}
+
+// Close ждёт завершения команд и останавливает обработчики очереди.
+// После Close вызывать Fire нельзя.
+func (r *Runner) Close() {
+	r.Wait()
+	close(r.queue)
+}
+
+func (r *Runner) work() {
+	for j := range r.queue {
+		// Команды, дождавшиеся очереди после отмены, не запускаются
+		if j.ctx.Err() == nil {
+			r.run(j.ctx, j.hook, j.event)
+		}
+		r.jobs.Done()
+	}
+}
+
+func (r *Runner) run(ctx context.Context, h Hook, e Event) {
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	input, err := json.Marshal(e)
+	if err != nil {
+		r.logln(fmt.Sprintf("%s: %v", h.Command, err))
+		return
+	}
+
+	cmd := shellCommand(ctx, h.Command)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Env = append(os.Environ(), Env(e)...)
+	// Дочерние процессы оболочки могут держать вывод открытым после
+	// завершения по таймауту
+	cmd.WaitDelay = time.Second
+
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		r.logln(i18n.T("hook %s: %q остановлен через %s (превышено время ожидания)", e.Type, h.Command, timeout))
+	case err != nil:
+		r.logln(i18n.T("hook %s: %q завершился с ошибкой за %s: %v", e.Type, h.Command, elapsed, err))
+	default:
+		r.logln(i18n.T("hook %s: %q выполнен за %s", e.Type, h.Command, elapsed))
+	}
+	if out := strings.TrimSpace(string(output)); out != "" {
+		r.logln("  " + out)
+	}
+}
+
+func (r *Runner) logln(line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fmt.Fprintln(r.log, line)
+}
+
+// Env возвращает переменные окружения с полями события.
+func Env(e Event) []string {
+	env := []string{
+		"RQMC_EVENT=" + e.Type,
+		"RQMC_TIME=" + e.Time.Format(time.RFC3339),
+	}
+	if e.Monster != "" {
+		env = append(env, "RQMC_MONSTER="+e.Monster, "RQMC_EXP="+strconv.Itoa(e.Exp))
+	}
+	if e.Level != 0 {
+		env = append(env, "RQMC_LEVEL="+strconv.Itoa(e.Level))
+	}
+	if e.Month != "" {
+		env = append(env, "RQMC_MONTH="+e.Month)
+	}
+	if s := e.Session; s != nil {
+		env = append(env,
+			"RQMC_SESSION_START="+s.Start.Format(time.RFC3339),
+			"RQMC_SESSION_END="+s.End.Format(time.RFC3339),
+			"RQMC_SESSION_KILLS="+strconv.Itoa(s.Kills),
+			"RQMC_SESSION_EXP="+strconv.Itoa(s.Exp),
+			"RQMC_SESSION_EXP_PER_HOUR="+strconv.Itoa(s.ExpPerHour),
+		)
+	}
+	return env
+}
+
+// shellCommand запускает строку через командную оболочку системы, чтобы в
+// конфиге можно было писать команды с аргументами и перенаправлениями.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
@@ -0,0 +1,156 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func skipWindows(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("команды в тестах написаны для sh")
+	}
+}
+
+func TestRunnerPassesEvent(t *testing.T) {
+	skipWindows(t)
+	dir := t.TempDir()
+	stdin := filepath.Join(dir, "stdin.json")
+	env := filepath.Join(dir, "env.txt")
+
+	var log bytes.Buffer
+	r := New([]Hook{
+		{Event: EventKill, Monster: "древний дракон", Command: "cat > " + stdin},
+		{Event: EventKill, Command: `echo "$RQMC_EVENT $RQMC_MONSTER $RQMC_EXP" > ` + env},
+		{Event: EventKill, Monster: "Часы", Command: "echo wrong monster"},
+		{Event: EventLevel, Command: "echo wrong event"},
+	}, 0, &log)
+
+	e := Event{Type: EventKill, Time: at(0), Monster: "Древний дракон", Exp: 5000}
+	r.Fire(context.Background(), e)
+	r.Wait()
+
+	data, err := os.ReadFile(stdin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Event
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("stdin %q: %v", data, err)
+	}
+	if got.Monster != e.Monster || got.Exp != e.Exp || !got.Time.Equal(e.Time) {
+		t.Errorf("stdin: got %+v, want %+v", got, e)
+	}
+
+	data, err = os.ReadFile(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.TrimSpace(string(data)), "kill Древний дракон 5000"; got != want {
+		t.Errorf("env: got %q, want %q", got, want)
+	}
+
+	if strings.Contains(log.String(), "wrong") || strings.Count(log.String(), "выполнен за") != 2 {
+		t.Errorf("log: got %q", log.String())
+	}
+}
+
+func TestRunnerTimeoutAndErrors(t *testing.T) {
+	skipWindows(t)
+	var log bytes.Buffer
+	r := New([]Hook{
+		{Event: EventRollover, Command: "sleep 5", Timeout: 50 * time.Millisecond},
+		{Event: EventRollover, Command: "echo oops; exit 3"},
+	}, 0, &log)
+
+	start := time.Now()
+	r.Fire(context.Background(), Event{Type: EventRollover, Month: "2026.02"})
+	r.Wait()
+
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Timeout not applied: took %s", elapsed)
+	}
+	for _, want := range []string{"превышено время ожидания", "завершился с ошибкой", "exit status 3", "  oops"} {
+		if !strings.Contains(log.String(), want) {
+			t.Errorf("log: missing %q in %q", want, log.String())
+		}
+	}
+}
+
+func TestRunnerConcurrencyLimit(t *testing.T) {
+	skipWindows(t)
+	trace := filepath.Join(t.TempDir(), "trace.txt")
+	command := "echo start >> " + trace + "; sleep 0.05; echo end >> " + trace
+
+	r := New([]Hook{
+		{Event: EventLevel, Command: command},
+		{Event: EventLevel, Command: command},
+		{Event: EventLevel, Command: command},
+	}, 1, &bytes.Buffer{})
+	r.Fire(context.Background(), Event{Type: EventLevel, Level: 10})
+	r.Wait()
+
+	data, err := os.ReadFile(trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Repeat("start\nend\n", 3)
+	if string(data) != want {
+		t.Errorf("trace: got %q, want %q", data, want)
+	}
+}
+
+func TestRunnerQueueOverflow(t *testing.T) {
+	skipWindows(t)
+	started := filepath.Join(t.TempDir(), "started")
+	command := "echo >> " + started + "; sleep 0.1"
+
+	var log bytes.Buffer
+	r := newRunner([]Hook{{Event: EventLevel, Command: command}}, 1, 1, &log)
+	defer r.Close()
+
+	r.Fire(context.Background(), Event{Type: EventLevel, Level: 10})
+	// Первая команда уже выполняется, вторая ждёт в очереди, третья не помещается
+	for deadline := time.Now().Add(2 * time.Second); ; time.Sleep(5 * time.Millisecond) {
+		if _, err := os.Stat(started); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("first command did not start")
+		}
+	}
+	r.Fire(context.Background(), Event{Type: EventLevel, Level: 11})
+	r.Fire(context.Background(), Event{Type: EventLevel, Level: 12})
+	r.Wait()
+
+	data, err := os.ReadFile(started)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs := strings.Count(string(data), "\n"); runs != 2 {
+		t.Errorf("Runs: got %d, want 2", runs)
+	}
+	if strings.Count(log.String(), "очередь команд переполнена") != 1 {
+		t.Errorf("log: got %q", log.String())
+	}
+}
+
+func TestEnv(t *testing.T) {
+	e := Event{Type: EventSessionEnd, Time: at(30), Session: &Session{Start: at(0), End: at(30), Kills: 5, Exp: 100, ExpPerHour: 200}}
+	env := strings.Join(Env(e), "\n")
+	for _, want := range []string{"RQMC_EVENT=session_end", "RQMC_SESSION_KILLS=5", "RQMC_SESSION_EXP_PER_HOUR=200"} {
+		if !strings.Contains(env, want) {
+			t.Errorf("Env: missing %q in %q", want, env)
+		}
+	}
+	if strings.Contains(env, "RQMC_MONSTER") {
+		t.Errorf("Env: unexpected monster in %q", env)
+	}
+}
@@ -0,0 +1,397 @@
+package i18n
+
+// english - английский каталог. Ключ - русский текст сообщения в коде.
+var english = map[string]string{
+	"\nПереопределено переменными окружения:": "\nOverridden by environment variables:",
+	"\nПорядок поиска:":                       "\nLookup order:",
+	"    %s:%d (смещение %d)\n":               "    %s:%d (offset %d)\n",
+	"  %d. %s (месяцев: %d, %s - %s)\n":       "  %d. %s (months: %d, %s - %s)\n",
+	"  ещё значений: %d":                      "  more values: %d",
+	" (в путях Windows обратную косую черту нужно удваивать: \"D:\\\\Games\\\\...\")": " (backslashes in Windows paths must be doubled: \"D:\\\\Games\\\\...\")",
+	"#%d %s (с %s)": "#%d %s (since %s)",
+	"%.1f КБ":       "%.1f KB",
+	"%.1f МБ":       "%.1f MB",
+	"%d Б":          "%d B",
+	"%q не является положительной длительностью, например \"10s\"": "%q is not a positive duration, e.g. \"10s\"",
+	"%s\n\nИспользование: %s\n\nФлаги:\n":                          "%s\n\nUsage: %s\n\nFlags:\n",
+	"%s (%d мес.)":   "%s (%d mo.)",
+	"%s (архив)":     "%s (archive)",
+	"%s (версия %d)": "%s (version %d)",
+	"%s - от меньшего к большему; максимум %s опыта в %s %02d:00":                            "%s - from less to more; peak %s exp on %s %02d:00",
+	"%s - от меньшего к большему; максимум %s убийств в %s %02d:00":                          "%s - from less to more; peak %s kills on %s %02d:00",
+	"%s - это файл, а не папка":                                                              "%s is a file, not a folder",
+	"%s записан в старой версии схемы, настройки переводятся в версию %d при каждом запуске": "%s uses an old schema version, settings are upgraded to version %d on every run",
+	"%s не найдена":                     "%s not found",
+	"%s, %s: убийств %s, опыт %s":       "%s, %s: %s kills, %s exp",
+	"%s, найдены: %s":                   "%s, found: %s",
+	"%s: не найдено ни одного убийства": "%s: no kills found",
+	"%s: опыт %s":                       "%s: %s exp",
+	"%s: убийств %s":                    "%s: %s kills",
+	"%s: убийств %s, опыт %s":           "%s: %s kills, %s exp",
+	"%s: убито %s":                      "%s: %s kills",
+	"(некорректный адрес)":              "(invalid address)",
+	"=== Новый месяц: %s ===":           "=== New month: %s ===",
+	"=== ОБЩАЯ СТАТИСТИКА ===":          "=== OVERALL STATISTICS ===",
+	"=== ПО ПЕРСОНАЖАМ ===":             "=== BY CHARACTER ===",
+	"RQ_MobCounter - статистика убийств монстров по логам Royal Quest": "RQ_MobCounter - monster kill statistics from Royal Quest logs",
+	"[обновляется]":                                                                                                      "[live]",
+	"defaults.color: %q, допустимо: %s":                                                                                  "defaults.color: %q, allowed: %s",
+	"defaults.columns: %q, допустимо: %s":                                                                                "defaults.columns: %q, allowed: %s",
+	"defaults.format: %q, допустимо: %s":                                                                                 "defaults.format: %q, allowed: %s",
+	"defaults.limit не может быть отрицательным":                                                                         "defaults.limit cannot be negative",
+	"defaults.min_count не может быть отрицательным":                                                                     "defaults.min_count cannot be negative",
+	"defaults.sort: %q, допустимо: %s":                                                                                   "defaults.sort: %q, allowed: %s",
+	"defaults.theme: %q, допустимо: %s":                                                                                  "defaults.theme: %q, allowed: %s",
+	"file_prefix %q должен быть только началом имени файла, например \"exp\"":                                            "file_prefix %q must be just the start of the file name, for example \"exp\"",
+	"file_prefix %q не должен начинаться или заканчиваться пробелом":                                                     "file_prefix %q must not start or end with a space",
+	"file_prefix %q содержит недопустимые символы (%s)":                                                                  "file_prefix %q contains invalid characters (%s)",
+	"file_prefix не может быть пустым":                                                                                   "file_prefix cannot be empty",
+	"hook %s: %q выполнен за %s":                                                                                         "hook %s: %q finished in %s",
+	"hook %s: %q завершился с ошибкой за %s: %v":                                                                         "hook %s: %q failed after %s: %v",
+	"hook %s: %q остановлен через %s (превышено время ожидания)":                                                         "hook %s: %q stopped after %s (timed out)",
+	"hooks.commands[%d].command не может быть пустым":                                                                    "hooks.commands[%d].command must not be empty",
+	"hooks.commands[%d].event: %q, допустимо: %s":                                                                        "hooks.commands[%d].event: %q, allowed: %s",
+	"hooks.commands[%d].monster: задаётся только для события kill":                                                       "hooks.commands[%d].monster: only allowed for the kill event",
+	"hooks.max_concurrent: значение не может быть отрицательным":                                                         "hooks.max_concurrent: value must not be negative",
+	"language: %q, допустимо: %s":                                                                                        "language: %q, allowed: %s",
+	"level работает с одним профилем, укажите --profile имя":                                                             "level works with a single profile, specify --profile name",
+	"log_path должен указывать на папку chatlogs":                                                                        "log_path must point to the chatlogs folder",
+	"log_path должен указывать на папку, а не на файл: %s":                                                               "log_path must point to a folder, not a file: %s",
+	"log_path не может быть пустым":                                                                                      "log_path cannot be empty",
+	"notify.milestones: значения должны быть больше нуля":                                                                "notify.milestones: values must be greater than zero",
+	"notify.webhooks[%d].events: %q, допустимо: %s":                                                                      "notify.webhooks[%d].events: %q, allowed: %s",
+	"notify.webhooks[%d].format: %q, допустимо: %s":                                                                      "notify.webhooks[%d].format: %q, allowed: %s",
+	"notify.webhooks[%d].url: %q не является адресом http или https":                                                     "notify.webhooks[%d].url: %q is not an http or https address",
+	"notify.webhooks[%d]: для формата telegram нужен chat_id":                                                            "notify.webhooks[%d]: the telegram format requires chat_id",
+	"profiles.%s: не указан log_path":                                                                                    "profiles.%s: log_path is not set",
+	"rqmc config path|show|migrate [--config путь] [--profile имя]":                                                      "rqmc config path|show|migrate [--config path] [--profile name]",
+	"rqmc doctor [--config путь]":                                                                                        "rqmc doctor [--config path]",
+	"rqmc export [--format csv|json] [--output файл] [--month YYYY.MM | --all] [флаги]":                                  "rqmc export [--format csv|json] [--output file] [--month YYYY.MM | --all] [flags]",
+	"rqmc goal add|list|rm [--kills N | --exp N] [--monster имя] [--since дата|today|week|month] [--name текст] [номер]": "rqmc goal add|list|rm [--kills N | --exp N] [--monster name] [--since date|today|week|month] [--name text] [number]",
+	"rqmc heatmap [--exp] [--ascii] [--month YYYY.MM | --all] [флаги]":                                                   "rqmc heatmap [--exp] [--ascii] [--month YYYY.MM | --all] [flags]",
+	"rqmc init [--root папка] [--log-path путь] [--prefix префикс] [--config путь | --here] [--yes]":                     "rqmc init [--root folder] [--log-path path] [--prefix prefix] [--config path | --here] [--yes]",
+	"rqmc level [--gap 15m] [--month YYYY.MM | --all] [флаги]":                                                           "rqmc level [--gap 15m] [--month YYYY.MM | --all] [flags]",
+	"rqmc months [--format table|json] [--profile имя|all]":                                                              "rqmc months [--format table|json] [--profile name|all]",
+	"rqmc report (--svg файл | --html файл) [--exp] [--gap 15m] [--month YYYY.MM | --all] [флаги]":                       "rqmc report (--svg file | --html file) [--exp] [--gap 15m] [--month YYYY.MM | --all] [flags]",
+	"rqmc serve [--addr 127.0.0.1:8080] [--config путь] [--profile имя|all]":                                             "rqmc serve [--addr 127.0.0.1:8080] [--config path] [--profile name|all]",
+	"rqmc sessions [--gap 15m] [--month YYYY.MM | --all] [флаги]":                                                        "rqmc sessions [--gap 15m] [--month YYYY.MM | --all] [flags]",
+	"rqmc stats [--exp] [--month YYYY.MM | --all] [флаги]":                                                               "rqmc stats [--exp] [--month YYYY.MM | --all] [flags]",
+	"rqmc trace --monster \"Имя\" [--month YYYY.MM | --all] [флаги]":                                                     "rqmc trace --monster \"Name\" [--month YYYY.MM | --all] [flags]",
+	"rqmc tui [--month YYYY.MM] [--sort count] [--interval 2s] [флаги]":                                                  "rqmc tui [--month YYYY.MM] [--sort count] [--interval 2s] [flags]",
+	"rqmc tui работает только в консоли":                                                                                 "rqmc tui only works in a console",
+	"rqmc watch [--interval 2s] [--gap 15m] [флаги]":                                                                     "rqmc watch [--interval 2s] [--gap 15m] [flags]",
+	"tui работает с одним профилем, укажите --profile имя":                                                               "tui works with a single profile, use --profile name",
+	"watch работает с одним профилем, укажите --profile имя":                                                             "watch works with a single profile, use --profile name",
+	"Архив": "Archive",
+	"Без команды выполняется stats: rqmc --exp равносильно rqmc stats --exp": "Without a command stats is run: rqmc --exp is the same as rqmc stats --exp",
+	"Больше": "More",
+	"В выбранных логах нет сообщений «Вы достигли N уровня!». Попробуйте --all": "The selected logs have no \"Вы достигли N уровня!\" messages. Try --all",
+	"В игре": "Played",
+	"В папке нет файлов вида «префикс (YYYY.MM).htm», используется префикс %s": "The folder has no \"prefix (YYYY.MM).htm\" files, using prefix %s",
+	"Введите путь к папке с логами":                                            "Enter the path to the log folder",
+	"Время убийств неизвестно":                                                 "Kill times are unknown",
+	"Вс":                 "Su",
+	"Все месяцы":         "All months",
+	"Всего":              "Total",
+	"Всего записей: %d":  "Total entries: %d",
+	"Всего опыта: %s":    "Total exp: %s",
+	"Всего сессий: %d":   "Total sessions: %d",
+	"Вт":                 "Tu",
+	"Выберите номер [1]": "Choose a number [1]",
+	"Выберите номер или введите свой путь [1]": "Choose a number or enter your own path [1]",
+	"Дата": "Date",
+	"Дашборд: http://%s (Ctrl+C для выхода)\n": "Dashboard: http://%s (Ctrl+C to exit)\n",
+	"Длит.":           "Length",
+	"Дней":            "Days",
+	"Дни без записей": "Days without entries",
+	"До %d уровня: ~%s игры при %s опыта в час": "To level %d: ~%s of play at %s exp per hour",
+	"Добавлена цель %s\n":                       "Added goal %s\n",
+	"Достигнут":                                 "Reached",
+	"Достигнут %d уровень":                      "Reached level %d",
+	"За час: убийств %s, опыт %s":               "Last hour: %s kills, %s exp",
+	"Использование: rqmc <команда> [флаги]":     "Usage: rqmc <command> [flags]",
+	"Используется: %s":                          "Using: %s",
+	"Использую значения по умолчанию.":          "Using default values.",
+	"Календарь активности":                      "Activity calendar",
+	"Календарь сохранён в %s":                   "Calendar saved to %s",
+	"Количество":                                "Count",
+	"Команды:":                                  "Commands:",
+	"Команды: %d\n":                             "Hook commands: %d\n",
+	"Конфиг":                                    "Config",
+	"Конфиг %s обновлён до версии %d":           "Config %s upgraded to version %d",
+	"Конфиг %s уже версии %d":                   "Config %s is already at version %d",
+	"Конфиг будет обновлён: %s\n":               "Config will be updated: %s\n",
+	"Конфиг будет создан: %s\n":                 "Config will be created: %s\n",
+	"Конфиг сохранён: %s":                       "Config saved: %s",
+	"Меньше":                                    "Less",
+	"Месяц":                                     "Month",
+	"Месяц: %s (%d/%d)":                         "Month: %s (%d/%d)",
+	"Месяцы":                                    "Months",
+	"Монстр":                                    "Monster",
+	"Монстров":                                  "Monsters",
+	"Монстры":                                   "Monsters",
+	"Нажмите на заголовок столбца, чтобы отсортировать таблицу": "Click a column header to sort the table",
+	"Найден префикс: %s (месяцев: %d)\n":                        "Found prefix: %s (months: %d)\n",
+	"Найдено убийств: %d":                                       "Kills found: %d",
+	"Найдены папки с логами:":                                   "Found log folders:",
+	"Найдены префиксы файлов:":                                  "Found file prefixes:",
+	"Начало": "Start",
+	"Нет данных для отображения": "No data to display",
+	"Нет сессий со временем":     "No timed sessions",
+	"Опыт": "Exp",
+	"Опыт для %d уровня неизвестен: добавьте строку «%d,опыт» в %s": "Exp for level %d is unknown: add a line \"%d,exp\" to %s",
+	"Опыт за убийство:":   "Exp per kill:",
+	"Опыт по дням":        "Exp per day",
+	"Опыт/час":            "Exp/hour",
+	"Отменено":            "Cancelled",
+	"Отчёт RQ MobCounter": "RQ MobCounter report",
+	"Отчёт сохранён в %s": "Report saved to %s",
+	"Папка chatlogs не найдена автоматически.": "The chatlogs folder was not found automatically.",
+	"Папка с логами":                           "Log folder",
+	"Папка с логами: %s\n":                     "Log folder: %s\n",
+	"Первая":                                   "First",
+	"Первое: %s, последнее: %s":                "First: %s, last: %s",
+	"Персонаж":                                 "Character",
+	"Пн":                                       "Mo",
+	"По дням":                                  "By day",
+	"По часам":                                 "By hour",
+	"Подробная сводка по месяцам: rqmc months": "Detailed summary by month: rqmc months",
+	"Поиск папки chatlogs...":                  "Searching for the chatlogs folder...",
+	"Последний":                                "Last",
+	"Последняя":                                "Last",
+	"Префикс":                                  "Prefix",
+	"Префикс файлов: %s\n":                     "File prefix: %s\n",
+	"Профиль %s":                               "Profile %s",
+	"Прошло":                                   "Elapsed",
+	"Пт":                                       "Fr",
+	"Размер":                                   "Size",
+	"Самые частые монстры":                     "Most killed monsters",
+	"Сб":                                       "Sa",
+	"Сессии":                                   "Sessions",
+	"Сессии по времени суток":                  "Sessions by time of day",
+	"Сессия %s: убийств %d, опыт %s, опыт/час %s":                       "Session %s: kills %d, exp %s, exp/hour %s",
+	"Слежение за %s (Ctrl+C для выхода)\n":                              "Watching %s (Ctrl+C to exit)\n",
+	"Со следующим содержимым:":                                          "With the following content:",
+	"Создайте файл config.json в одном из мест:":                        "Create config.json in one of these locations:",
+	"Сортировка: %s":                                                    "Sort: %s",
+	"Сохранено монстров: %d в %s":                                       "Saved %d monsters to %s",
+	"Сохранить?":                                                        "Save?",
+	"Справка по команде: rqmc help <команда> или rqmc <команда> --help": "Command help: rqmc help <command> or rqmc <command> --help",
+	"Ср":             "We",
+	"Средний":        "Average",
+	"Средний опыт":   "Average exp",
+	"Строк":          "Rows",
+	"Суммарный опыт": "Total exp",
+	"Сформирован":    "Generated",
+	"Текущий месяц":  "Current month",
+	"Теперь можно запускать: rqmc --exp": "You can now run: rqmc --exp",
+	"Убийств":  "Kills",
+	"Убийства": "Kills",
+	"Убит редкий монстр: %s (+%s опыта)": "Rare monster killed: %s (+%s exp)",
+	"Уведомления: вебхуков %d\n":         "Notifications: %d webhooks\n",
+	"Удалено дубликатов: %d":             "Duplicates removed: %d",
+	"Уровень": "Level",
+	"Уровень %d, опыт с повышения %s":               "Level %d, exp since level-up %s",
+	"Уровень %d: %s / %s опыта (%d%%), осталось %s": "Level %d: %s / %s exp (%d%%), %s to go",
+	"Файл %s": "File %s",
+	"Файл конфига не найден, используются значения по умолчанию": "Config file not found, using default values",
+	"Файлы логов": "Log files",
+	"Фильтр":      "Filter",
+	"Фильтр: %s":  "Filter: %s",
+	"Фильтр: %s_   Enter - готово, Esc - сбросить":                 "Filter: %s_   Enter - done, Esc - clear",
+	"Целей нет. Добавьте: rqmc goal add --kills 500 --monster имя": "No goals. Add one: rqmc goal add --kills 500 --monster name",
+	"Цель #%d удалена\n":                                           "Goal #%d removed\n",
+	"Цель выполнена: %s":                                           "Goal completed: %s",
+	"Чаще всего":                                                   "Most killed",
+	"Чт":                                                           "Th",
+	"авг":                                                          "Aug",
+	"адрес и порт сервера":                                         "server address and port",
+	"активность по дням недели и часам":                            "activity by weekday and hour",
+	"анализ конкретного месяца (YYYY.MM)":                          "analyse a specific month (YYYY.MM)",
+	"апр": "Apr",
+	"архив пропускается; проверьте, что он не повреждён": "the archive is skipped; check that it is not corrupted",
+	"архив пропущен: %v":                              "archive skipped: %v",
+	"без дубликатов":                                  "without duplicates",
+	"в конфиге нет секции profiles":                   "the config has no profiles section",
+	"в папке нет файлов вида «префикс (YYYY.MM).htm»": "the folder has no \"prefix (YYYY.MM).htm\" files",
+	"ввод не является консолью":                       "input is not a console",
+	"включите в игре Настройки → Чат → ✓ Сохранять историю сообщений": "enable Settings → Chat → ✓ Save message history in the game",
+	"все монстры": "all monsters",
+	"все убийства монстра с указанием файла, строки и исходного текста": "every kill of a monster with its file, line and raw text",
+	"вывод не является консолью":                                        "output is not a console",
+	"выгрузка полной статистики в CSV или JSON":                         "export full statistics as CSV or JSON",
+	"выполнена %s": "completed %s",
+	"дек":          "Dec",
+	"дополнительная папка для поиска chatlogs (можно указать несколько раз)":                                                   "extra folder to search for chatlogs (can be repeated)",
+	"если вы играли в этом месяце, игра не сохраняет историю: проверьте настройку чата и попробуйте полноэкранный режим":       "if you played this month, the game is not saving history: check the chat setting and try full-screen mode",
+	"если вы играли за это время, игра перестала сохранять историю: проверьте настройку чата и попробуйте полноэкранный режим": "if you played since then, the game stopped saving history: check the chat setting and try full-screen mode",
+	"закрашивать календарь SVG по опыту, а не по убийствам":                                                                    "shade the SVG calendar by exp instead of kills",
+	"закрашивать по опыту, а не по убийствам":                                                                                  "shade by exp instead of kills",
+	"запускать команды из секции hooks конфига":                                                                                "run commands from the hooks section of the config",
+	"игровые сессии: длительность, убийства и опыт в час":                                                                      "play sessions: duration, kills and exp per hour",
+	"имя монстра": "monster name",
+	"имя монстра (сравнивается после нормализации)":                         "monster name (compared after normalisation)",
+	"исправьте ошибку в конфиге или создайте его заново командой rqmc init": "fix the error in the config or recreate it with rqmc init",
+	"история уровней персонажа и время до следующего уровня":                "character level history and time to the next level",
+	"июл": "Jul",
+	"июн": "Jun",
+	"как часто проверять файл": "how often to check the file",
+	"какой файл конфига используется, итоговые настройки и обновление схемы":                 "which config file is used, the resulting settings and schema upgrades",
+	"колонка с убийствами по дням за выбранный период":                                       "column with kills per day over the selected range",
+	"колонки CSV через запятую: name, count, exp, avg":                                       "comma-separated CSV columns: name, count, exp, avg",
+	"колонки через запятую: name, count, exp, avg (по умолчанию name,count и exp при --exp)": "comma-separated columns: name, count, exp, avg (default name,count plus exp with --exp)",
+	"конфиг версии %d создан более новой версией приложения (поддерживается до %d)":          "config version %d was created by a newer version of the application (up to %d is supported)",
+	"конфиг должен быть JSON объектом":                                                       "the config must be a JSON object",
+	"куда сохранить конфиг":                                                                  "where to save the config",
+	"локальный веб-дашборд и JSON API со статистикой":                                        "local web dashboard and JSON API with statistics",
+	"май": "May",
+	"максимальное количество записей для отображения (0 - без ограничений)": "maximum number of rows to display (0 - no limit)",
+	"мар": "Mar",
+	"мастер первоначальной настройки: поиск папки chatlogs и создание конфига": "first-run setup: find the chatlogs folder and create the config",
+	"месяц, открытый при запуске (YYYY.MM), по умолчанию последний":            "month to open at start (YYYY.MM), the latest by default",
+	"название цели (для add)": "goal name (for add)",
+	"начало отсчёта: YYYY-MM-DD [HH:MM], today, week или month; по умолчанию сейчас (для add)": "start of counting: YYYY-MM-DD [HH:MM], today, week or month; defaults to now (for add)",
+	"не задавать вопросов: выбрать первую найденную папку и самый частый префикс":              "do not ask questions: pick the first folder found and the most common prefix",
+	"не удалось перевести консоль в полноэкранный режим: %w":                                   "cannot switch the console to full-screen mode: %w",
+	"недопустимое имя профиля %q":                                                              "invalid profile name %q",
+	"неизвестная команда %q":                                                                   "unknown command %q",
+	"неизвестное поле %q, возможно, имелось в виду %q":                                         "unknown field %q, did you mean %q",
+	"неизвестное поле %q, допустимые поля: %s":                                                 "unknown field %q, allowed fields: %s",
+	"неизвестный параметр %q":                                                                  "unknown parameter %q",
+	"неизвестный формат вебхука %q":                                                            "unknown webhook format %q",
+	"некорректное время %q: %w":                                                                "invalid time %q: %w",
+	"некорректное значение %q: нужно положительное число, например 500 или 10M":                "invalid value %q: expected a positive number such as 500 or 10M",
+	"некорректное значение %s=%q: %w":                                                          "invalid value %s=%q: %w",
+	"некорректное значение --%s=%s, допустимо: %s":                                             "invalid value --%s=%s, allowed: %s",
+	"некорректное значение по умолчанию для --%s: %w":                                          "invalid default value for --%s: %w",
+	"некорректное начало отсчёта %q: нужно YYYY-MM-DD [HH:MM], today, week или month":          "invalid start %q: expected YYYY-MM-DD [HH:MM], today, week or month",
+	"некорректный конфиг: %w":                                                                  "invalid config: %w",
+	"некорректный месяц %q: %w":                                                                "invalid month %q: %w",
+	"некорректный номер цели %q":                                                               "invalid goal number %q",
+	"нет данных для календаря":                                                                 "no data for the calendar",
+	"нет данных для отчёта":                                                                    "no data for the report",
+	"нет доступа к %s: %v":                                                                     "cannot access %s: %v",
+	"нет файлов для обработки":                                                                 "no files to process",
+	"ноя": "Nov",
+	"обновите файл командой rqmc config migrate": "update the file with rqmc config migrate",
+	"обновлён %s":           "updated %s",
+	"обработка всех файлов": "process all files",
+	"оверлей работает с одним профилем, укажите profile=имя": "the overlay works with a single profile, use profile=name",
+	"окт": "Oct",
+	"осталось ~%s игры (%s в час)":                                           "~%s of play left (%s per hour)",
+	"отправлять уведомления на вебхуки из секции notify конфига":             "send notifications to the webhooks from the notify section of the config",
+	"отчёт в файл: календарь активности в SVG или страница HTML с графиками": "report to a file: activity calendar as SVG or an HTML page with charts",
+	"очередь команд переполнена, пропущено: hook %s: %q":                     "hook queue is full, skipped: hook %s: %q",
+	"очередь уведомлений переполнена, пропущено: %s":                         "notification queue is full, dropped: %s",
+	"ошибка загрузки конфига: %w":                                            "failed to load config: %w",
+	"ошибка загрузки существующего конфига: %w":                              "failed to load existing config: %w",
+	"ошибка записи %s: %w":                                                   "failed to write %s: %w",
+	"ошибка миграции конфига с версии %d: %w":                                "failed to migrate config from version %d: %w",
+	"ошибка миграции конфига: %w":                                            "failed to migrate config: %w",
+	"ошибка определения папки настроек пользователя: %w":                     "failed to determine the user config folder: %w",
+	"ошибка определения пути приложения: %w":                                 "failed to determine application path: %w",
+	"ошибка открытия архива %s: %w":                                          "failed to open archive %s: %w",
+	"ошибка отправки на %s: %w":                                              "failed to send to %s: %w",
+	"ошибка отправки уведомления: %v":                                        "failed to send notification: %v",
+	"ошибка парсинга конфига %s: %w":                                         "failed to parse config %s: %w",
+	"ошибка поиска конфига: %w":                                              "failed to locate config: %w",
+	"ошибка при парсинге %s: %v":                                             "failed to parse %s: %v",
+	"ошибка при чтении %s: %v":                                               "failed to read %s: %v",
+	"ошибка разбора %s: %w":                                                  "failed to parse %s: %w",
+	"ошибка распаковки %s: %w":                                               "failed to decompress %s: %w",
+	"ошибка сериализации конфига: %w":                                        "failed to serialise config: %w",
+	"ошибка создания папки конфига: %w":                                      "failed to create config folder: %w",
+	"ошибка сохранения конфига: %w":                                          "failed to save config: %w",
+	"ошибка сохранения целей: %w":                                            "failed to save goals: %w",
+	"ошибка формирования %s: %w":                                             "failed to build %s: %w",
+	"ошибка формирования CSV: %w":                                            "failed to build CSV: %w",
+	"ошибка формирования JSON: %w":                                           "failed to build JSON: %w",
+	"ошибка чтения директории: %w":                                           "failed to read directory: %w",
+	"ошибка чтения конфига: %w":                                              "failed to read config: %w",
+	"ошибка чтения лога: %v":                                                 "failed to read log: %v",
+	"ошибка чтения файла: %w":                                                "failed to read file: %w",
+	"ошибка: %v": "error: %v",
+	"папка chatlogs не найдена, укажите её через --log-path или --root":                      "chatlogs folder not found, specify it with --log-path or --root",
+	"папка настроек пользователя":                                                            "user config folder",
+	"папка приложения":                                                                       "application folder",
+	"папка с логами не найдена: %s":                                                          "log folder not found: %s",
+	"перерыв, после которого начинается новая сессия":                                        "break after which a new session starts",
+	"показать настройки профиля (для show)":                                                  "show the settings of a profile (for show)",
+	"показывать исходные имена, объединённые в одно":                                         "show the raw names merged into each name",
+	"показывать опыт":                                                                        "show exp",
+	"показывать только монстров, в имени которых есть подстрока":                             "show only monsters whose name contains the substring",
+	"показывать только монстров, убитых не меньше N раз":                                     "show only monsters killed at least N times",
+	"поле version должно быть целым неотрицательным числом, получено %v":                     "the version field must be a non-negative integer, got %v",
+	"полноэкранный просмотр: таблица монстров с сортировкой, фильтром и подробностями":       "full-screen browser: monster table with sorting, filter and details",
+	"полоса рядом с каждой строкой: count (по количеству) или exp (по опыту)":                "bar next to each row: count (by kills) or exp (by exp)",
+	"похоже на испорченную кодировку (встречается %q)":                                       "looks like broken encoding (contains %q)",
+	"префикс файлов логов (без определения)":                                                 "log file prefix (skips detection)",
+	"проверка конфига и логов с подсказками по исправлению":                                  "check the config and logs with suggested fixes",
+	"проверьте права доступа к папке":                                                        "check the folder permissions",
+	"проверьте, что файл не повреждён":                                                       "check that the file is not corrupted",
+	"профиль %q не найден, доступные: %s":                                                    "profile %q not found, available: %s",
+	"профиль %q не найден: в конфиге нет секции profiles":                                    "profile %q not found: the config has no profiles section",
+	"профиль %s пропущен: %v":                                                                "profile %s skipped: %v",
+	"профиль персонажа из конфига или all для всех профилей":                                 "character profile from the config, or all for every profile",
+	"путь к логам не найден: %s":                                                             "log path not found: %s",
+	"путь к папке с логами (без поиска)":                                                     "path to the log folder (skips the search)",
+	"путь к файлу конфига":                                                                   "path to the config file",
+	"рабочая папка":                                                                          "working folder",
+	"размер консоли неизвестен":                                                              "console size is unknown",
+	"рисовать графики символами ASCII (по умолчанию - если консоль не поддерживает Unicode)": "draw charts with ASCII characters (default when the console does not support Unicode)",
+	"рисовать символами ASCII вместо блоков Unicode":                                         "draw with ASCII characters instead of Unicode blocks",
+	"сводка по месяцам: размер файла, строки, убийства, опыт и дни без записей":              "summary by month: file size, rows, kills, exp and days without entries",
+	"сен": "Sep",
+	"синтаксическая ошибка в строке %d, позиция %d: %v":                                              "syntax error at line %d, column %d: %v",
+	"следить за логом текущего месяца и выводить новые убийства":                                     "watch the current month log and print new kills",
+	"создайте конфиг командой rqmc init":                                                             "create a config with rqmc init",
+	"сообщать о выполнении целей из rqmc goal":                                                       "report completed goals from rqmc goal",
+	"сортировка: count (по количеству), exp (по опыту), avg (по среднему опыту) или name (по имени)": "sort by: count, exp, avg (average exp) or name",
+	"сортировка: count, exp, avg или name":                                                           "sort by: count, exp, avg or name",
+	"сохранить календарь активности в SVG":                                                           "save the activity calendar as SVG",
+	"сохранить конфиг в текущей папке (./config.json)":                                               "save the config in the current folder (./config.json)",
+	"сохранить отчёт с графиками и таблицами в HTML":                                                 "save a report with charts and tables as HTML",
+	"статистика убийств по монстрам (команда по умолчанию)":                                          "kill statistics by monster (default command)",
+	"строка %d: %q, ожидается «уровень,опыт»":                                                        "line %d: %q, expected \"level,exp\"",
+	"сырой режим консоли не поддерживается":                                                          "raw console mode is not supported",
+	"текущий": "current",
+	"только монстры, в имени которых есть подстрока":                                 "only monsters whose name contains the substring",
+	"только монстры, убитые не меньше N раз":                                         "only monsters killed at least N times",
+	"убедитесь, что в этот чат попадают сообщения «... погибает. Получено опыта: N»": "make sure this chat receives the \"... погибает. Получено опыта: N\" messages",
+	"убийств: %d": "kills: %d",
+	"убийства монстра %q не найдены":                                     "no kills of monster %q found",
+	"удалять повторяющиеся записи из перекрывающихся логов":              "remove repeated entries from overlapping logs",
+	"укажите \"file_prefix\": %q в конфиге":                              "set \"file_prefix\": %q in the config",
+	"укажите монстра: rqmc trace --monster \"Имя\"":                      "specify a monster: rqmc trace --monster \"Name\"",
+	"укажите одно из --kills или --exp":                                  "specify exactly one of --kills or --exp",
+	"укажите правильный log_path в конфиге или запустите rqmc init":      "set the correct log_path in the config or run rqmc init",
+	"укажите файл отчёта: --svg файл или --html файл":                    "specify the report file: --svg file or --html file",
+	"учитывать только монстров, в имени которых есть подстрока":          "count only monsters whose name contains the substring",
+	"файл %s не найден в архиве %s":                                      "file %s not found in archive %s",
+	"файл config.json не найден, используются значения по умолчанию":     "config.json not found, using default values",
+	"файл для месяца %s не найден":                                       "no file for month %s",
+	"файл для сохранения (по умолчанию вывод в консоль)":                 "file to save to (prints to the console by default)",
+	"файл для текущего месяца %s не найден. Доступные файлы:\n":          "no file for the current month %s. Available files:\n",
+	"файл должен быть в UTF-8, не пересохраняйте его в другой кодировке": "the file must be UTF-8, do not re-save it in another encoding",
+	"файл конфига %s (%s) не найден: %w":                                 "config file %s (%s) not found: %w",
+	"файл конфига не найден, создайте его командой rqmc init":            "config file not found, create it with rqmc init",
+	"файл не в кодировке UTF-8":                                          "the file is not UTF-8",
+	"файл не обновлялся %d дн. (последнее изменение %s)":                 "the file has not been updated for %d days (last modified %s)",
+	"файла за %s нет":                        "no file for %s",
+	"файлов с префиксом %q нет, найдены: %s": "no files with prefix %q, found: %s",
+	"фев":               "Feb",
+	"формат вывода: %s": "output format: %s",
+	"цветной вывод: auto (только в консоли и без NO_COLOR), always или never":                "coloured output: auto (only in a console and without NO_COLOR), always or never",
+	"цветной вывод: auto, always или never":                                                  "coloured output: auto, always or never",
+	"цветовая тема таблицы: default, top (выделить первые 10), exp (выделить опыт) или none": "table colour theme: default, top (highlight the first 10), exp (highlight exp) or none",
+	"цели не загружены: %v":                                                                  "goals not loaded: %v",
+	"цели по убийствам и опыту: добавление, прогресс и удаление":                             "kill and exp goals: add, track progress and remove",
+	"цель #%d не найдена":                                                                    "goal #%d not found",
+	"цель относится к одному персонажу, укажите --profile имя":                               "a goal belongs to one character, specify --profile name",
+	"цель по количеству убийств (для add)":                                                   "kill count target (for add)",
+	"цель по опыту, можно с суффиксом k или M: 10M (для add)":                                "exp target, k or M suffix allowed: 10M (for add)",
+	"часть имени монстра, без него считаются все (для add)":                                  "part of the monster name, all monsters if omitted (for add)",
+	"янв": "Jan",
+	"↑↓ выбор  ←→ месяц  s сортировка  / фильтр  Esc сбросить фильтр  q выход": "↑↓ select  ←→ month  s sort  / filter  Esc clear filter  q quit",
+	"⚠️ Предупреждение: файл config.json не найден!":                           "⚠️ Warning: config.json not found!",
+}
@@ -0,0 +1,101 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Сообщения пишутся в коде по-русски, русский текст служит ключом в
+// каталогах остальных языков (как msgid в gettext). Если перевода нет,
+// выводится русский текст.
+
+const (
+	Russian = "ru"
+	English = "en"
+)
+
+var Languages = []string{Russian, English}
+
+var catalogs = map[string]map[string]string{
+	Russian: nil,
+	English: english,
+}
+
+var current = Russian
+
+// Detect определяет язык по переменным окружения LC_ALL, LC_MESSAGES и
+// LANG (в этом порядке). Если язык не указан или не поддерживается,
+// используется русский.
+func Detect() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(env)
+		if value == "" {
+			continue
+		}
+		if lang, ok := Parse(value); ok {
+			return lang
+		}
+		return Russian
+	}
+	return Russian
+}
+
+// Parse приводит значение вида "en", "en_US.UTF-8" или "ru-RU" к коду
+// поддерживаемого языка.
+func Parse(value string) (string, bool) {
+	lang := strings.ToLower(value)
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	if _, ok := catalogs[lang]; ok {
+		return lang, true
+	}
+	return "", false
+}
+
+func SetLanguage(lang string) {
+	if _, ok := catalogs[lang]; ok {
+		current = lang
+	}
+}
+
+func Language() string {
+	return current
+}
+
+// T переводит сообщение на текущий язык и, если переданы аргументы,
+// форматирует его как fmt.Sprintf.
+func T(msg string, args ...any) string {
+	if translated, ok := catalogs[current][msg]; ok {
+		msg = translated
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// N только помечает строку для перевода и возвращает её без изменений.
+// Используется там, где строка задаётся до выбора языка (например, в
+// переменных пакета), а переводится позже через T.
+func N(msg string) string {
+	return msg
+}
+
+// Errorf работает как fmt.Errorf с переведённым форматом.
+func Errorf(format string, args ...any) error {
+	if translated, ok := catalogs[current][format]; ok {
+		format = translated
+	}
+	return fmt.Errorf(format, args...)
+}
+
+// ThousandsSeparator возвращает разделитель разрядов: пробел для русского
+// и запятую для английского.
+func ThousandsSeparator() string {
+	if current == English {
+		return ","
+	}
+	return " "
+}
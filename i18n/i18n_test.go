@@ -0,0 +1,198 @@
+package i18n
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		lcAll, lcMessages, lang string
+		expected                string
+	}{
+		{"", "", "", Russian},
+		{"", "", "en_US.UTF-8", English},
+		{"", "", "ru_RU.UTF-8", Russian},
+		{"", "", "de_DE.UTF-8", Russian},
+		{"", "", "C", Russian},
+		{"", "en_GB", "ru_RU.UTF-8", English},
+		// LC_ALL важнее LANG
+		{"ru_RU.UTF-8", "", "en_US.UTF-8", Russian},
+		{"en", "ru", "ru", English},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_MESSAGES", tt.lcMessages)
+		t.Setenv("LANG", tt.lang)
+		if got := Detect(); got != tt.expected {
+			t.Errorf("Detect(LC_ALL=%q, LC_MESSAGES=%q, LANG=%q): got %q, want %q",
+				tt.lcAll, tt.lcMessages, tt.lang, got, tt.expected)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		ok       bool
+	}{
+		{"en", English, true},
+		{"EN", English, true},
+		{"en_US.UTF-8", English, true},
+		{"ru-RU", Russian, true},
+		{"ru@euro", Russian, true},
+		{"de", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Parse(tt.value)
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf("Parse(%q): got %q, %v, want %q, %v", tt.value, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	defer SetLanguage(Language())
+
+	SetLanguage(Russian)
+	if got := T("Всего опыта: %s", "1 000"); got != "Всего опыта: 1 000" {
+		t.Errorf("T russian: got %q", got)
+	}
+
+	SetLanguage(English)
+	if got := T("Всего опыта: %s", "1,000"); got != "Total exp: 1,000" {
+		t.Errorf("T english: got %q", got)
+	}
+	// Без аргументов строка не форматируется
+	if got := T("100%"); got != "100%" {
+		t.Errorf("T without args: got %q", got)
+	}
+	// Нет перевода - остаётся русский текст
+	if got := T("Непереведённая строка"); got != "Непереведённая строка" {
+		t.Errorf("T fallback: got %q", got)
+	}
+	if got := Errorf("некорректный месяц %q: %w", "13", errors.New("test")).Error(); got != `invalid month "13": test` {
+		t.Errorf("Errorf: got %q", got)
+	}
+
+	SetLanguage("de")
+	if Language() != English {
+		t.Errorf("SetLanguage should ignore unsupported languages, got %q", Language())
+	}
+}
+
+func TestThousandsSeparator(t *testing.T) {
+	defer SetLanguage(Language())
+
+	SetLanguage(Russian)
+	if got := ThousandsSeparator(); got != " " {
+		t.Errorf("ThousandsSeparator russian: got %q", got)
+	}
+	SetLanguage(English)
+	if got := ThousandsSeparator(); got != "," {
+		t.Errorf("ThousandsSeparator english: got %q", got)
+	}
+}
+
+var verbPattern = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+// TestEnglishCatalog проверяет, что у каждой русской строки в коде есть
+// перевод и что в переводе те же аргументы форматирования.
+func TestEnglishCatalog(t *testing.T) {
+	keys := messageKeys(t, "..")
+	if len(keys) == 0 {
+		t.Fatal("no messages found")
+	}
+
+	for key, pos := range keys {
+		translated, ok := english[key]
+		if !ok {
+			t.Errorf("%s: no english translation for %q", pos, key)
+			continue
+		}
+		want := verbPattern.FindAllString(key, -1)
+		got := verbPattern.FindAllString(translated, -1)
+		if strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("%q: verbs %v, want %v", translated, got, want)
+		}
+	}
+
+	for key := range english {
+		if _, ok := keys[key]; !ok {
+			t.Errorf("unused translation %q", key)
+		}
+	}
+}
+
+// messageKeys собирает русские строки, переданные первым аргументом в
+// i18n.T, i18n.N, i18n.Errorf и ctx.printf.
+func messageKeys(t *testing.T, root string) map[string]string {
+	t.Helper()
+	keys := make(map[string]string)
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			x, ok := sel.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			switch {
+			case x.Name == "i18n" && (sel.Sel.Name == "T" || sel.Sel.Name == "N" || sel.Sel.Name == "Errorf"):
+			case x.Name == "ctx" && sel.Sel.Name == "printf":
+			default:
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			value, err := strconv.Unquote(lit.Value)
+			if err == nil && strings.IndexFunc(value, isCyrillic) >= 0 {
+				keys[value] = fset.Position(lit.Pos()).String()
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func isCyrillic(r rune) bool {
+	return unicode.Is(unicode.Cyrillic, r)
+}
@@ -0,0 +1,208 @@
+package levels
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/parser"
+	"RQ_MobCounter/stats"
+)
+
+// FileName - имя файла с таблицей опыта в папке конфига.
+const FileName = "levels.csv"
+
+//go:embed exp.csv
+var defaultTable string
+
+// Table - опыт, нужный для перехода с уровня на следующий.
+type Table map[int]int
+
+// Default возвращает встроенную таблицу.
+func Default() Table {
+	t, err := ParseTable(strings.NewReader(defaultTable))
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// ParseTable читает строки «уровень,опыт». Пустые строки, комментарии с #
+// и заголовок level,exp пропускаются.
+func ParseTable(r io.Reader) (Table, error) {
+	table := make(Table)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.EqualFold(line, "level,exp") {
+			continue
+		}
+
+		level, exp, ok := strings.Cut(line, ",")
+		l, err1 := strconv.Atoi(strings.TrimSpace(level))
+		e, err2 := strconv.Atoi(strings.TrimSpace(exp))
+		if !ok || err1 != nil || err2 != nil || l <= 0 || e <= 0 {
+			return nil, i18n.Errorf("строка %d: %q, ожидается «уровень,опыт»", n, line)
+		}
+		table[l] = e
+	}
+	return table, scanner.Err()
+}
+
+// Merge возвращает таблицу t, дополненную и переопределённую значениями other.
+func (t Table) Merge(other Table) Table {
+	result := make(Table, len(t)+len(other))
+	for l, e := range t {
+		result[l] = e
+	}
+	for l, e := range other {
+		result[l] = e
+	}
+	return result
+}
+
+// Step - время, проведённое на одном уровне.
+type Step struct {
+	Level   int
+	Reached time.Time
+	// Left - момент перехода на следующий уровень, нулевой для текущего.
+	Left  time.Time
+	Kills int
+	Exp   int
+	// PlayTime - суммарная длительность игровых сессий на этом уровне.
+	PlayTime time.Duration
+}
+
+func (s Step) Complete() bool {
+	return !s.Left.IsZero()
+}
+
+// Timeline строит историю уровней: для каждого повышения - убийства и опыт
+// до следующего повышения. Записи до первого повышения не учитываются,
+// потому что уровень в них неизвестен.
+func Timeline(ups []parser.LevelUp, entries []parser.LogEntry, gap time.Duration) []Step {
+	var timed []parser.LevelUp
+	for _, u := range ups {
+		if !u.Time.IsZero() {
+			timed = append(timed, u)
+		}
+	}
+	sort.SliceStable(timed, func(i, j int) bool {
+		return timed[i].Time.Before(timed[j].Time)
+	})
+
+	steps := make([]Step, len(timed))
+	stepEntries := make([][]parser.LogEntry, len(timed))
+	for i, u := range timed {
+		steps[i] = Step{Level: u.Level, Reached: u.Time}
+		if i+1 < len(timed) {
+			steps[i].Left = timed[i+1].Time
+		}
+	}
+
+	for _, e := range entries {
+		if e.MonsterName == "" || e.Time.IsZero() {
+			continue
+		}
+		// Последний шаг, начавшийся раньше записи. Убийство, после которого
+		// пришло сообщение о новом уровне, обычно записано в ту же секунду
+		// и относится к предыдущему уровню
+		i := sort.Search(len(steps), func(i int) bool { return !steps[i].Reached.Before(e.Time) }) - 1
+		if i < 0 {
+			continue
+		}
+		steps[i].Kills++
+		steps[i].Exp += e.ExpGained
+		stepEntries[i] = append(stepEntries[i], e)
+	}
+
+	for i := range steps {
+		for _, s := range stats.Sessions(stepEntries[i], gap) {
+			steps[i].PlayTime += s.Duration()
+		}
+	}
+	return steps
+}
+
+// Estimate - прогресс текущего уровня.
+type Estimate struct {
+	Level int
+	// Gained - опыт с момента повышения, Need - опыт для следующего уровня
+	// из таблицы (0, если его там нет).
+	Gained int
+	Need   int
+	// Rate - опыт в час за последнюю сессию.
+	Rate int
+}
+
+// Current оценивает прогресс по последнему шагу истории. Скорость берётся
+// из последней игровой сессии.
+func Current(steps []Step, table Table, entries []parser.LogEntry, gap time.Duration) (Estimate, bool) {
+	if len(steps) == 0 {
+		return Estimate{}, false
+	}
+	last := steps[len(steps)-1]
+	e := Estimate{Level: last.Level, Gained: last.Exp, Need: table[last.Level]}
+	if sessions := stats.Sessions(entries, gap); len(sessions) > 0 {
+		e.Rate = sessions[len(sessions)-1].ExpPerHour()
+	}
+	return e, true
+}
+
+func (e Estimate) Remaining() int {
+	return max(e.Need-e.Gained, 0)
+}
+
+// Fraction - доля пройденного уровня от 0 до 1, 0 - если нет данных.
+func (e Estimate) Fraction() float64 {
+	if e.Need <= 0 {
+		return 0
+	}
+	return min(float64(e.Gained)/float64(e.Need), 1)
+}
+
+// ETA - сколько ещё играть до следующего уровня, 0 - неизвестно.
+func (e Estimate) ETA() time.Duration {
+	if e.Need <= 0 || e.Rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(e.Remaining()) / float64(e.Rate) * float64(time.Hour))
+}
+
+// FormatTable выводит историю уровней таблицей.
+func FormatTable(steps []Step) string {
+	if len(steps) == 0 {
+		return i18n.T("Нет данных для отображения") + "\n"
+	}
+
+	output := fmt.Sprintf("%7s | %-16s | %9s | %8s | %10s | %14s | %12s\n",
+		i18n.T("Уровень"), i18n.T("Достигнут"), i18n.T("Прошло"), i18n.T("В игре"), i18n.T("Убийств"), i18n.T("Опыт"), i18n.T("Опыт/час"))
+	output += strings.Repeat("-", 96) + "\n"
+
+	for _, s := range steps {
+		elapsed := i18n.T("текущий")
+		if s.Complete() {
+			elapsed = stats.FormatDuration(s.Left.Sub(s.Reached))
+		}
+		perHour := 0
+		if s.PlayTime >= time.Minute {
+			perHour = int(float64(s.Exp) / s.PlayTime.Hours())
+		}
+		output += fmt.Sprintf("%7d | %-16s | %9s | %8s | %10d | %14s | %12s\n",
+			s.Level,
+			s.Reached.Format("2006-01-02 15:04"),
+			elapsed,
+			stats.FormatDuration(s.PlayTime),
+			s.Kills,
+			stats.FormatNumberForDisplay(s.Exp),
+			stats.FormatNumberForDisplay(perHour))
+	}
+
+	return output
+}
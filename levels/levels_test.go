@@ -0,0 +1,109 @@
+package levels
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"RQ_MobCounter/parser"
+)
+
+func kill(exp int, minute int) parser.LogEntry {
+	return parser.LogEntry{MonsterName: "Часы", ExpGained: exp, Time: at(minute)}
+}
+
+func levelUp(level int, minute int) parser.LevelUp {
+	return parser.LevelUp{Level: level, Time: at(minute)}
+}
+
+func at(minute int) time.Time {
+	return time.Date(2026, 1, 16, 10, 0, 0, 0, time.Local).Add(time.Duration(minute) * time.Minute)
+}
+
+func TestParseTable(t *testing.T) {
+	table, err := ParseTable(strings.NewReader("# комментарий\nlevel,exp\n\n1, 100\n2,250\n"))
+	if err != nil || len(table) != 2 || table[1] != 100 || table[2] != 250 {
+		t.Errorf("ParseTable: got %v, %v", table, err)
+	}
+
+	if _, err := ParseTable(strings.NewReader("1,100\n2;250\n")); err == nil || !strings.Contains(err.Error(), "строка 2") {
+		t.Errorf("Invalid line: got %v", err)
+	}
+
+	if _, err := ParseTable(strings.NewReader(defaultTable)); err != nil {
+		t.Errorf("Embedded table: %v", err)
+	}
+
+	merged := Table{1: 100, 2: 200}.Merge(Table{2: 250, 3: 400})
+	if len(merged) != 3 || merged[2] != 250 {
+		t.Errorf("Merge: got %v", merged)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	entries := []parser.LogEntry{
+		kill(10, 0), // до первого повышения
+		kill(20, 5), // в ту же секунду, что и повышение - ещё 41 уровень
+		kill(30, 6),
+		kill(40, 16),
+		kill(50, 60),
+	}
+	ups := []parser.LevelUp{levelUp(42, 30), levelUp(41, 5)}
+
+	steps := Timeline(ups, entries, 15*time.Minute)
+	if len(steps) != 2 {
+		t.Fatalf("Timeline: got %+v", steps)
+	}
+
+	s := steps[0]
+	if s.Level != 41 || !s.Complete() || s.Kills != 2 || s.Exp != 70 || s.PlayTime != 10*time.Minute {
+		t.Errorf("Level 41: got %+v", s)
+	}
+	s = steps[1]
+	if s.Level != 42 || s.Complete() || s.Kills != 1 || s.Exp != 50 || s.PlayTime != 0 {
+		t.Errorf("Level 42: got %+v", s)
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	entries := []parser.LogEntry{kill(100, 0), kill(100, 30), kill(100, 60)}
+	steps := Timeline([]parser.LevelUp{levelUp(10, -1)}, entries, time.Hour)
+
+	e, ok := Current(steps, Table{10: 1200}, entries, time.Hour)
+	if !ok || e.Level != 10 || e.Gained != 300 || e.Need != 1200 || e.Rate != 300 {
+		t.Fatalf("Current: got %+v, %v", e, ok)
+	}
+	if e.Remaining() != 900 || e.Fraction() != 0.25 || e.ETA() != 3*time.Hour {
+		t.Errorf("Estimate: got remaining %d, fraction %v, eta %s", e.Remaining(), e.Fraction(), e.ETA())
+	}
+
+	if e, _ := Current(steps, Table{}, entries, time.Hour); e.ETA() != 0 || e.Fraction() != 0 {
+		t.Errorf("Unknown level: got %+v", e)
+	}
+	// Таблица из файла, как levels.csv рядом с конфигом
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("level,exp\n42,3000\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	table, err := ParseTable(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	big := []parser.LogEntry{kill(500, 0), kill(500, 30), kill(500, 60)}
+	steps = Timeline([]parser.LevelUp{levelUp(42, -1)}, big, time.Hour)
+	e, ok = Current(steps, Default().Merge(table), big, time.Hour)
+	if !ok || e.Need != 3000 || e.Remaining() != 1500 || e.ETA() != time.Hour {
+		t.Errorf("Table from file: got %+v, eta %s", e, e.ETA())
+	}
+
+	if _, ok := Current(nil, Table{}, entries, time.Hour); ok {
+		t.Errorf("No level-ups: expected no estimate")
+	}
+}
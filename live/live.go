@@ -0,0 +1,120 @@
+package live
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"RQ_MobCounter/logfiles"
+	"RQ_MobCounter/parser"
+)
+
+const DefaultInterval = 2 * time.Second
+
+type Update struct {
+	Entries  []parser.LogEntry
+	Levels   []parser.LevelUp
+	Month    string
+	Rollover bool
+	// Reset - файл текущего месяца перезаписан или усечён: Entries и Levels
+	// содержат его целиком и заменяют полученные раньше.
+	Reset bool
+}
+
+// Watcher следит за файлом текущего месяца и возвращает новые записи по
+// мере их появления. При смене месяца переключается на новый файл.
+type Watcher struct {
+	dir    string
+	prefix string
+	now    func() time.Time
+
+	month   string
+	path    string
+	size    int64
+	modTime time.Time
+	count   int
+	levels  int
+}
+
+func New(dir, prefix string) *Watcher {
+	return &Watcher{dir: dir, prefix: prefix, now: time.Now}
+}
+
+func (w *Watcher) SetClock(now func() time.Time) {
+	w.now = now
+}
+
+// Poll возвращает записи, появившиеся с прошлого вызова. Первый вызов
+// возвращает все записи текущего файла.
+func (w *Watcher) Poll() (Update, error) {
+	now := w.now()
+	month := fmt.Sprintf("%d.%02d", now.Year(), now.Month())
+
+	update := Update{Month: month}
+	if w.month != "" && w.month != month {
+		update.Rollover = true
+		w.path, w.count, w.levels, w.size = "", 0, 0, 0
+	}
+	w.month = month
+
+	// Poll вызывается каждые несколько секунд, поэтому вместо полного
+	// поиска с открытием архивов проверяется только файл текущего месяца
+	file, found, err := logfiles.FindPlain(w.dir, w.prefix, month)
+	if err != nil || !found {
+		return update, err
+	}
+
+	info, err := os.Stat(file.Path)
+	if err != nil {
+		return update, err
+	}
+
+	if file.Path == w.path && info.Size() == w.size && info.ModTime().Equal(w.modTime) {
+		return update, nil
+	}
+
+	doc, err := logfiles.LoadDocument(file)
+	if err != nil {
+		return update, err
+	}
+
+	// Файл перезаписан или усечён - начинаем заново
+	if file.Path != w.path || len(doc.Entries) < w.count || len(doc.Levels) < w.levels {
+		update.Reset = w.path != ""
+		w.count, w.levels = 0, 0
+	}
+
+	update.Entries = doc.Entries[w.count:]
+	update.Levels = doc.Levels[w.levels:]
+	w.path = file.Path
+	w.size = info.Size()
+	w.modTime = info.ModTime()
+	w.count = len(doc.Entries)
+	w.levels = len(doc.Levels)
+
+	return update, nil
+}
+
+// Run вызывает Poll с интервалом interval до отмены ctx и передаёт каждое
+// непустое обновление в handle.
+func (w *Watcher) Run(ctx context.Context, interval time.Duration, handle func(Update), onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		update, err := w.Poll()
+		if err != nil && onError != nil {
+			onError(err)
+		}
+		if len(update.Entries) > 0 || len(update.Levels) > 0 || update.Rollover || update.Reset {
+			handle(update)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
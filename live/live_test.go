@@ -0,0 +1,100 @@
+package live
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func row(ts, text string) string {
+	return "<TR style='color:#4A92D3' valign=top title='" + ts + "'><TD colspan=2>" + text + "\n"
+}
+
+func appendFile(t *testing.T, path, content string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+}
+
+func TestWatcherPoll(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "exp (2026.01).htm")
+	appendFile(t, path, row("1/16 06:45:41", "Часы погибает. Получено опыта: 17530."))
+
+	now := time.Date(2026, 1, 16, 7, 0, 0, 0, time.Local)
+	w := New(dir, "exp")
+	w.SetClock(func() time.Time { return now })
+
+	update, err := w.Poll()
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if len(update.Entries) != 1 || update.Entries[0].MonsterName != "Часы" {
+		t.Fatalf("First poll should return existing entries, got %+v", update.Entries)
+	}
+	if update.Entries[0].Time.IsZero() {
+		t.Errorf("Entries should have time set")
+	}
+
+	update, _ = w.Poll()
+	if len(update.Entries) != 0 {
+		t.Errorf("Poll without changes should return nothing, got %d", len(update.Entries))
+	}
+
+	appendFile(t, path, row("1/16 06:46:00", "Росинка погибает. Получено опыта: 3."))
+	// Размер файла изменился, даже если время изменения то же
+	update, _ = w.Poll()
+	if len(update.Entries) != 1 || update.Entries[0].MonsterName != "Росинка" {
+		t.Errorf("Poll should return only new entries, got %+v", update.Entries)
+	}
+
+	appendFile(t, path, row("1/16 06:47:00", "Вы достигли 12 уровня!"))
+	update, _ = w.Poll()
+	if len(update.Entries) != 0 || len(update.Levels) != 1 || update.Levels[0].Level != 12 || update.Levels[0].Time.IsZero() {
+		t.Errorf("Poll should return new level-ups, got %+v", update)
+	}
+	if update.Reset {
+		t.Errorf("Appending should not reset, got %+v", update)
+	}
+
+	// Перезаписанный файл возвращается целиком с флагом Reset
+	if err := os.WriteFile(path, []byte(row("1/16 06:48:00", "Часы погибает. Получено опыта: 17530.")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	update, _ = w.Poll()
+	if !update.Reset || len(update.Entries) != 1 || update.Entries[0].Timestamp != "1/16 06:48:00" {
+		t.Errorf("Poll after truncation: got %+v", update)
+	}
+}
+
+func TestWatcherRollover(t *testing.T) {
+	dir := t.TempDir()
+	appendFile(t, filepath.Join(dir, "exp (2026.01).htm"), row("1/31 23:59:00", "Часы погибает. Получено опыта: 1."))
+
+	now := time.Date(2026, 1, 31, 23, 59, 30, 0, time.Local)
+	w := New(dir, "exp")
+	w.SetClock(func() time.Time { return now })
+	w.Poll()
+
+	now = time.Date(2026, 2, 1, 0, 1, 0, 0, time.Local)
+	update, err := w.Poll()
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if !update.Rollover || update.Month != "2026.02" || len(update.Entries) != 0 {
+		t.Errorf("Expected rollover to 2026.02 without entries, got %+v", update)
+	}
+
+	appendFile(t, filepath.Join(dir, "exp (2026.02).htm"), row("2/1 00:00:30", "Росинка погибает. Получено опыта: 3."))
+	update, _ = w.Poll()
+	if update.Rollover || len(update.Entries) != 1 {
+		t.Errorf("Expected new month entries, got %+v", update)
+	}
+}
@@ -0,0 +1,147 @@
+package logfiles
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+const chatlogsDir = "chatlogs"
+
+type PrefixInfo struct {
+	Prefix string
+	Months []string
+}
+
+// FindChatlogDirs ищет в roots папки chatlogs, в которых есть хотя бы один
+// файл вида «префикс (YYYY.MM).htm». Глубина поиска ограничена maxDepth.
+func FindChatlogDirs(roots []string, maxDepth int) []string {
+	seen := make(map[string]bool)
+	var found []string
+
+	for _, root := range roots {
+		walk(root, 0, maxDepth, func(dir string) {
+			abs, err := filepath.Abs(dir)
+			if err != nil {
+				abs = dir
+			}
+			if !seen[abs] {
+				seen[abs] = true
+				found = append(found, abs)
+			}
+		})
+	}
+
+	return found
+}
+
+func walk(dir string, depth, maxDepth int, found func(string)) {
+	if strings.EqualFold(filepath.Base(dir), chatlogsDir) {
+		if prefixes, err := Prefixes(dir); err == nil && len(prefixes) > 0 {
+			found(dir)
+			return
+		}
+	}
+
+	if depth >= maxDepth {
+		return
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		walk(filepath.Join(dir, entry.Name()), depth+1, maxDepth, found)
+	}
+}
+
+// Prefixes возвращает префиксы файлов логов в папке, начиная с самого
+// частого (по количеству месяцев).
+func Prefixes(dir string) ([]PrefixInfo, error) {
+	files, _, err := scan(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	months := make(map[string]map[string]bool)
+	for _, f := range files {
+		if months[f.Prefix] == nil {
+			months[f.Prefix] = make(map[string]bool)
+		}
+		months[f.Prefix][f.Month] = true
+	}
+
+	var result []PrefixInfo
+	for prefix, set := range months {
+		info := PrefixInfo{Prefix: prefix}
+		for month := range set {
+			info.Months = append(info.Months, month)
+		}
+		sort.Strings(info.Months)
+		result = append(result, info)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].Months) != len(result[j].Months) {
+			return len(result[i].Months) > len(result[j].Months)
+		}
+		return result[i].Prefix < result[j].Prefix
+	})
+
+	return result, nil
+}
+
+// DefaultSearchRoots возвращает существующие папки, в которые обычно
+// устанавливают Royal Quest.
+func DefaultSearchRoots() []string {
+	var candidates []string
+
+	home, _ := os.UserHomeDir()
+
+	if runtime.GOOS == "windows" {
+		for _, env := range []string{"ProgramFiles", "ProgramFiles(x86)", "LOCALAPPDATA", "APPDATA"} {
+			if dir := os.Getenv(env); dir != "" {
+				candidates = append(candidates, dir)
+			}
+		}
+		for drive := 'C'; drive <= 'Z'; drive++ {
+			root := string(drive) + `:\`
+			candidates = append(candidates,
+				filepath.Join(root, "Games"),
+				filepath.Join(root, "Royal Quest"),
+				filepath.Join(root, "Program Files"),
+				filepath.Join(root, "Program Files (x86)"),
+			)
+		}
+		if home != "" {
+			candidates = append(candidates, filepath.Join(home, "Games"), filepath.Join(home, "Documents"))
+		}
+	} else if home != "" {
+		candidates = append(candidates,
+			filepath.Join(home, "Games"),
+			filepath.Join(home, ".wine", "drive_c"),
+			filepath.Join(home, ".local", "share", "Steam", "steamapps", "compatdata"),
+		)
+	}
+
+	seen := make(map[string]bool)
+	var roots []string
+	for _, dir := range candidates {
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			roots = append(roots, dir)
+		}
+	}
+
+	return roots
+}
@@ -0,0 +1,62 @@
+package logfiles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func touch(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("<HTML></HTML>"), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestFindChatlogDirs(t *testing.T) {
+	root := t.TempDir()
+
+	game := filepath.Join(root, "Games", "Royal Quest", "chatlogs")
+	touch(t, filepath.Join(game, "exp (2026.01).htm"))
+
+	empty := filepath.Join(root, "Other", "chatlogs")
+	touch(t, filepath.Join(empty, "readme.txt"))
+
+	deep := filepath.Join(root, "a", "b", "c", "d", "e", "chatlogs")
+	touch(t, filepath.Join(deep, "exp (2026.01).htm"))
+
+	found := FindChatlogDirs([]string{root, root}, 4)
+
+	if len(found) != 1 {
+		t.Fatalf("Expected 1 chatlogs dir, got %v", found)
+	}
+	if found[0] != game {
+		t.Errorf("Found dir: got %q, want %q", found[0], game)
+	}
+}
+
+func TestPrefixes(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, filepath.Join(dir, "exp (2025.12).htm"))
+	touch(t, filepath.Join(dir, "exp (2026.01).htm"))
+	touch(t, filepath.Join(dir, "battle (2026.01).htm"))
+	touch(t, filepath.Join(dir, "notes.htm"))
+
+	prefixes, err := Prefixes(dir)
+	if err != nil {
+		t.Fatalf("Prefixes failed: %v", err)
+	}
+
+	if len(prefixes) != 2 {
+		t.Fatalf("Expected 2 prefixes, got %v", prefixes)
+	}
+	if prefixes[0].Prefix != "exp" || len(prefixes[0].Months) != 2 {
+		t.Errorf("First prefix should be exp with 2 months, got %+v", prefixes[0])
+	}
+	if prefixes[1].Prefix != "battle" || prefixes[1].Months[0] != "2026.01" {
+		t.Errorf("Second prefix should be battle, got %+v", prefixes[1])
+	}
+}
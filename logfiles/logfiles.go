@@ -0,0 +1,325 @@
+package logfiles
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/parser"
+)
+
+type Kind int
+
+const (
+	Plain Kind = iota
+	Gzip
+	Zip
+)
+
+type File struct {
+	Path   string
+	Entry  string
+	Prefix string
+	Month  string
+	Kind   Kind
+}
+
+var nameRegex = regexp.MustCompile(`^(.+) \((\d{4}\.\d{2})\)\.htm(\.gz)?$`)
+
+func (f File) Name() string {
+	if f.Kind == Zip {
+		return f.Path + "!" + f.Entry
+	}
+	return f.Path
+}
+
+func (f File) Open() (io.ReadCloser, error) {
+	switch f.Kind {
+	case Gzip:
+		file, err := os.Open(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, i18n.Errorf("ошибка распаковки %s: %w", f.Path, err)
+		}
+		return &multiCloser{Reader: gz, closers: []io.Closer{gz, file}}, nil
+	case Zip:
+		archive, err := zip.OpenReader(f.Path)
+		if err != nil {
+			return nil, i18n.Errorf("ошибка открытия архива %s: %w", f.Path, err)
+		}
+		for _, entry := range archive.File {
+			if entry.Name != f.Entry {
+				continue
+			}
+			rc, err := entry.Open()
+			if err != nil {
+				archive.Close()
+				return nil, i18n.Errorf("ошибка распаковки %s: %w", f.Name(), err)
+			}
+			return &multiCloser{Reader: rc, closers: []io.Closer{rc, archive}}, nil
+		}
+		archive.Close()
+		return nil, i18n.Errorf("файл %s не найден в архиве %s", f.Entry, f.Path)
+	default:
+		return os.Open(f.Path)
+	}
+}
+
+func (f File) Year() int {
+	year, _ := strconv.Atoi(f.Month[:4])
+	return year
+}
+
+// Load разбирает файл и заполняет время записей с учётом года из имени файла.
+func Load(f File) ([]parser.LogEntry, error) {
+	doc, err := LoadDocument(f)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Entries, nil
+}
+
+// Info - сведения о файле лога для сводки по месяцам.
+type Info struct {
+	File    File
+	Size    int64
+	Rows    int
+	Entries []parser.LogEntry
+}
+
+// Inspect разбирает файл и возвращает его размер на диске (для файла в
+// архиве - сжатый размер), количество строк и записи.
+func Inspect(f File) (Info, error) {
+	size, err := f.Size()
+	if err != nil {
+		return Info{}, err
+	}
+
+	doc, err := LoadDocument(f)
+	if err != nil {
+		return Info{}, err
+	}
+
+	return Info{File: f, Size: size, Rows: doc.Rows, Entries: doc.Entries}, nil
+}
+
+func (f File) Size() (int64, error) {
+	if f.Kind != Zip {
+		info, err := os.Stat(f.Path)
+		if err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	}
+
+	archive, err := zip.OpenReader(f.Path)
+	if err != nil {
+		return 0, i18n.Errorf("ошибка открытия архива %s: %w", f.Path, err)
+	}
+	defer archive.Close()
+
+	for _, entry := range archive.File {
+		if entry.Name == f.Entry {
+			return int64(entry.CompressedSize64), nil
+		}
+	}
+	return 0, i18n.Errorf("файл %s не найден в архиве %s", f.Entry, f.Path)
+}
+
+// LoadDocument разбирает файл целиком: записи об убийствах и повышения
+// уровня со временем, восстановленным по году из имени файла.
+func LoadDocument(f File) (parser.Document, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return parser.Document{}, err
+	}
+	defer rc.Close()
+
+	doc, err := parser.ParseDocument(rc, f.Name())
+	if err != nil {
+		return parser.Document{}, err
+	}
+
+	year := f.Year()
+	for i := range doc.Entries {
+		if t, err := parser.ParseTimestamp(doc.Entries[i].Timestamp, year); err == nil {
+			doc.Entries[i].Time = t
+		}
+	}
+	for i := range doc.Levels {
+		if t, err := parser.ParseTimestamp(doc.Levels[i].Timestamp, year); err == nil {
+			doc.Levels[i].Time = t
+		}
+	}
+
+	return doc, nil
+}
+
+// Discover возвращает файлы логов с указанным префиксом, по одному на месяц,
+// отсортированные по месяцу. Если месяц есть и в обычном файле, и в архиве,
+// предпочтение отдаётся обычному файлу, затем .gz, затем .zip. Архивы,
+// которые не удалось открыть, пропускаются.
+func Discover(dir, prefix string) ([]File, error) {
+	files, _, err := Scan(dir, prefix)
+	return files, err
+}
+
+// Scan делает то же, что Discover, и дополнительно возвращает ошибки
+// пропущенных архивов, чтобы о них можно было предупредить.
+func Scan(dir, prefix string) ([]File, []error, error) {
+	all, skipped, err := scan(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byMonth := make(map[string]File)
+	for _, f := range all {
+		if f.Prefix != prefix {
+			continue
+		}
+		if existing, ok := byMonth[f.Month]; !ok || f.Kind < existing.Kind {
+			byMonth[f.Month] = f
+		}
+	}
+
+	files := make([]File, 0, len(byMonth))
+	for _, f := range byMonth {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Month < files[j].Month
+	})
+
+	return files, skipped, nil
+}
+
+func Find(dir, prefix, month string) (File, bool, error) {
+	files, err := Discover(dir, prefix)
+	if err != nil {
+		return File{}, false, err
+	}
+
+	for _, f := range files {
+		if f.Month == month {
+			return f, true, nil
+		}
+	}
+	return File{}, false, nil
+}
+
+// FindPlain ищет несжатый файл за месяц, не просматривая папку и архивы.
+// Игра пишет текущий месяц только в такой файл, поэтому слежению за логом
+// полный поиск не нужен.
+func FindPlain(dir, prefix, month string) (File, bool, error) {
+	path := filepath.Join(dir, fmt.Sprintf("%s (%s).htm", prefix, month))
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return File{}, false, nil
+	}
+	if err != nil {
+		return File{}, false, err
+	}
+	if info.IsDir() {
+		return File{}, false, nil
+	}
+	return File{Path: path, Prefix: prefix, Month: month, Kind: Plain}, true, nil
+}
+
+// scan собирает файлы логов в папке. Повреждённый архив не мешает
+// остальным файлам: его ошибка возвращается в skipped.
+func scan(dir string) (files []File, skipped []error, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, i18n.Errorf("ошибка чтения директории: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+
+		if filepath.Ext(entry.Name()) == ".zip" {
+			inner, err := scanZip(path)
+			if err != nil {
+				skipped = append(skipped, err)
+				continue
+			}
+			files = append(files, inner...)
+			continue
+		}
+
+		if f, ok := parseName(entry.Name()); ok {
+			f.Path = path
+			files = append(files, f)
+		}
+	}
+
+	return files, skipped, nil
+}
+
+func scanZip(path string) ([]File, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка открытия архива %s: %w", path, err)
+	}
+	defer archive.Close()
+
+	var files []File
+	for _, entry := range archive.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		f, ok := parseName(filepath.Base(entry.Name))
+		if !ok || f.Kind != Plain {
+			continue
+		}
+		f.Path = path
+		f.Entry = entry.Name
+		f.Kind = Zip
+		files = append(files, f)
+	}
+
+	return files, nil
+}
+
+func parseName(name string) (File, bool) {
+	matches := nameRegex.FindStringSubmatch(name)
+	if matches == nil {
+		return File{}, false
+	}
+
+	f := File{Prefix: matches[1], Month: matches[2], Kind: Plain}
+	if matches[3] != "" {
+		f.Kind = Gzip
+	}
+	return f, true
+}
+
+type multiCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (m *multiCloser) Close() error {
+	var first error
+	for _, c := range m.closers {
+		if err := c.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
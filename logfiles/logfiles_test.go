@@ -0,0 +1,254 @@
+package logfiles
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeGzip(t *testing.T, path, content string) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	if _, err := gz.Write([]byte(content)); err != nil {
+		t.Fatalf("Failed to write gzip: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Failed to close gzip: %v", err)
+	}
+}
+
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to add %s to zip: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write %s to zip: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to close zip: %v", err)
+	}
+}
+
+func readAll(t *testing.T, f File) string {
+	t.Helper()
+	rc, err := f.Open()
+	if err != nil {
+		t.Fatalf("Open %s failed: %v", f.Name(), err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("Read %s failed: %v", f.Name(), err)
+	}
+	return string(data)
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+
+	os.WriteFile(filepath.Join(dir, "exp (2026.01).htm"), []byte("plain-01"), 0644)
+	os.WriteFile(filepath.Join(dir, "chat (2026.01).htm"), []byte("other prefix"), 0644)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644)
+	writeGzip(t, filepath.Join(dir, "exp (2025.12).htm.gz"), "gzip-12")
+	writeGzip(t, filepath.Join(dir, "exp (2026.01).htm.gz"), "gzip-01")
+	writeZip(t, filepath.Join(dir, "archive.zip"), map[string]string{
+		"2025/exp (2025.11).htm": "zip-11",
+		"exp (2025.12).htm":      "zip-12",
+		"readme.txt":             "ignored",
+	})
+
+	files, err := Discover(dir, "exp")
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	expected := []struct {
+		month   string
+		kind    Kind
+		content string
+	}{
+		{"2025.11", Zip, "zip-11"},
+		{"2025.12", Gzip, "gzip-12"},
+		{"2026.01", Plain, "plain-01"},
+	}
+
+	if len(files) != len(expected) {
+		t.Fatalf("Expected %d files, got %d: %v", len(expected), len(files), files)
+	}
+
+	for i, exp := range expected {
+		if files[i].Month != exp.month {
+			t.Errorf("File %d month: got %q, want %q", i, files[i].Month, exp.month)
+		}
+		if files[i].Kind != exp.kind {
+			t.Errorf("File %d kind: got %d, want %d", i, files[i].Kind, exp.kind)
+		}
+		if content := readAll(t, files[i]); content != exp.content {
+			t.Errorf("File %d content: got %q, want %q", i, content, exp.content)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	writeGzip(t, filepath.Join(dir, "exp (2025.12).htm.gz"), "gzip-12")
+
+	file, found, err := Find(dir, "exp", "2025.12")
+	if err != nil || !found {
+		t.Fatalf("Find should locate archived month, found=%v err=%v", found, err)
+	}
+	if file.Kind != Gzip {
+		t.Errorf("Expected gzip file, got kind %d", file.Kind)
+	}
+
+	_, found, err = Find(dir, "exp", "2026.01")
+	if err != nil || found {
+		t.Errorf("Find should not locate missing month, found=%v err=%v", found, err)
+	}
+}
+
+func TestFindPlain(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "exp (2026.01).htm"), []byte("plain-01"), 0644)
+	writeGzip(t, filepath.Join(dir, "exp (2025.12).htm.gz"), "gzip-12")
+
+	file, found, err := FindPlain(dir, "exp", "2026.01")
+	if err != nil || !found || file.Kind != Plain || readAll(t, file) != "plain-01" {
+		t.Errorf("FindPlain(2026.01): got %+v, found=%v err=%v", file, found, err)
+	}
+	// Архивы не просматриваются
+	if _, found, err := FindPlain(dir, "exp", "2025.12"); err != nil || found {
+		t.Errorf("FindPlain(2025.12): found=%v err=%v, want not found", found, err)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	plain := File{Path: "logs/exp (2026.01).htm", Kind: Plain}
+	if plain.Name() != "logs/exp (2026.01).htm" {
+		t.Errorf("Plain name: got %q", plain.Name())
+	}
+
+	zipped := File{Path: "logs/archive.zip", Entry: "exp (2025.11).htm", Kind: Zip}
+	if zipped.Name() != "logs/archive.zip!exp (2025.11).htm" {
+		t.Errorf("Zip name: got %q", zipped.Name())
+	}
+}
+
+func TestScanSkipsBrokenArchive(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "exp (2026.01).htm"), []byte("plain-01"), 0644)
+	os.WriteFile(filepath.Join(dir, "broken.zip"), []byte("not a zip"), 0644)
+
+	files, skipped, err := Scan(dir, "exp")
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(files) != 1 || files[0].Month != "2026.01" {
+		t.Errorf("Scan files: got %v, want only 2026.01", files)
+	}
+	if len(skipped) != 1 || !strings.Contains(skipped[0].Error(), "broken.zip") {
+		t.Errorf("Scan skipped: got %v, want broken.zip", skipped)
+	}
+
+	if _, found, err := Find(dir, "exp", "2026.01"); err != nil || !found {
+		t.Errorf("Find with broken archive: found=%v err=%v", found, err)
+	}
+}
+
+func TestDiscoverMissingDir(t *testing.T) {
+	if _, err := Discover(filepath.Join(t.TempDir(), "missing"), "exp"); err == nil {
+		t.Errorf("Discover should fail for missing directory")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	row := "<TR style='color:#4A92D3' valign=top title='12/31 23:59:59'><TD colspan=2>Часы погибает. Получено опыта: 17530.\n"
+	writeGzip(t, filepath.Join(dir, "exp (2025.12).htm.gz"), row)
+
+	file, found, err := Find(dir, "exp", "2025.12")
+	if err != nil || !found {
+		t.Fatalf("Find failed: found=%v err=%v", found, err)
+	}
+
+	entries, err := Load(file)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+
+	expected := time.Date(2025, 12, 31, 23, 59, 59, 0, time.Local)
+	if !entries[0].Time.Equal(expected) {
+		t.Errorf("Entry time: got %v, want %v", entries[0].Time, expected)
+	}
+	if entries[0].Source != file.Name() {
+		t.Errorf("Entry source: got %q, want %q", entries[0].Source, file.Name())
+	}
+}
+
+func TestInspect(t *testing.T) {
+	dir := t.TempDir()
+	content := "<TR style='color:#4A92D3' valign=top title='1/16 06:45:41'><TD colspan=2>Часы погибает. Получено опыта: 17530.\n" +
+		"<TR style='color:#4A92D3' valign=top title='1/16 06:58:30'><TD colspan=2>Вы достигли 2 уровня!\n"
+	if err := os.WriteFile(filepath.Join(dir, "exp (2026.01).htm"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeZip(t, filepath.Join(dir, "old.zip"), map[string]string{"exp (2025.12).htm": content})
+
+	files, err := Discover(dir, "exp")
+	if err != nil || len(files) != 2 {
+		t.Fatalf("Discover: got %d files, err %v", len(files), err)
+	}
+
+	for _, f := range files {
+		info, err := Inspect(f)
+		if err != nil {
+			t.Fatalf("Inspect %s failed: %v", f.Name(), err)
+		}
+		if info.Rows != 2 || len(info.Entries) != 1 {
+			t.Errorf("%s: got %d rows and %d entries, want 2 and 1", f.Name(), info.Rows, len(info.Entries))
+		}
+		if info.Size <= 0 {
+			t.Errorf("%s: got size %d", f.Name(), info.Size)
+		}
+	}
+
+	doc, err := LoadDocument(files[0])
+	if err != nil {
+		t.Fatalf("LoadDocument failed: %v", err)
+	}
+	want := time.Date(2025, 1, 16, 6, 58, 30, 0, time.Local)
+	if len(doc.Levels) != 1 || !doc.Levels[0].Time.Equal(want) {
+		t.Errorf("LoadDocument levels: got %+v, want time %v", doc.Levels, want)
+	}
+
+	plain, _ := Inspect(files[1])
+	if plain.Size != int64(len(content)) {
+		t.Errorf("Plain size: got %d, want %d", plain.Size, len(content))
+	}
+}
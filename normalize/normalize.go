@@ -0,0 +1,167 @@
+package normalize
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+type Options struct {
+	Prefixes []string
+	Suffixes []string
+	Aliases  map[string]string
+}
+
+type Normalizer struct {
+	prefixes  []string
+	suffixes  []string
+	aliases   map[string]string
+	canonical map[string]string
+}
+
+var bracketRegex = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)|\{[^}]*\}`)
+
+const trailingPunct = ".,!?:;…-–— "
+
+func New(opts Options) *Normalizer {
+	n := &Normalizer{
+		aliases:   make(map[string]string),
+		canonical: make(map[string]string),
+	}
+
+	for _, p := range opts.Prefixes {
+		if p = cleanup(p); p != "" {
+			n.prefixes = append(n.prefixes, strings.ToLower(p))
+		}
+	}
+	for _, s := range opts.Suffixes {
+		if s = cleanup(s); s != "" {
+			n.suffixes = append(n.suffixes, strings.ToLower(s))
+		}
+	}
+
+	// Ключи алиасов проходят ту же обработку, что и имена из логов,
+	// поэтому в конфиге можно писать их в любом регистре и с «ё».
+	for from, to := range opts.Aliases {
+		key := strings.ToLower(n.strip(cleanup(from)))
+		to = strings.TrimSpace(to)
+		if key != "" && to != "" {
+			n.aliases[key] = to
+		}
+	}
+
+	return n
+}
+
+// Name возвращает каноническое имя монстра. Имена, отличающиеся только
+// регистром, приводятся к первому встреченному написанию.
+func (n *Normalizer) Name(raw string) string {
+	name := n.strip(cleanup(raw))
+	if name == "" {
+		return strings.TrimSpace(raw)
+	}
+
+	key := strings.ToLower(name)
+	if alias, ok := n.aliases[key]; ok {
+		return alias
+	}
+
+	if display, ok := n.canonical[key]; ok {
+		return display
+	}
+	n.canonical[key] = name
+
+	return name
+}
+
+func (n *Normalizer) strip(name string) string {
+	name = bracketRegex.ReplaceAllString(name, " ")
+	name = strings.Join(strings.Fields(name), " ")
+
+	for changed := true; changed; {
+		changed = false
+		for _, p := range n.prefixes {
+			if rest, ok := cutPrefix(name, p); ok {
+				name, changed = rest, true
+			}
+		}
+		for _, s := range n.suffixes {
+			if rest, ok := cutSuffix(name, s); ok {
+				name, changed = rest, true
+			}
+		}
+		name = strings.TrimRight(name, trailingPunct)
+	}
+
+	return name
+}
+
+func cleanup(s string) string {
+	s = strings.ReplaceAll(s, "ё", "е")
+	s = strings.ReplaceAll(s, "Ё", "Е")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func cutPrefix(name, prefix string) (string, bool) {
+	lower := strings.ToLower(name)
+	if !strings.HasPrefix(lower, prefix) {
+		return name, false
+	}
+
+	rest := name[len(prefix):]
+	if !isBoundary(prefix, rest, true) {
+		return name, false
+	}
+
+	rest = strings.TrimLeft(rest, trailingPunct)
+	if rest == "" {
+		return name, false
+	}
+	return rest, true
+}
+
+func cutSuffix(name, suffix string) (string, bool) {
+	lower := strings.ToLower(name)
+	if !strings.HasSuffix(lower, suffix) {
+		return name, false
+	}
+
+	rest := name[:len(name)-len(suffix)]
+	if !isBoundary(suffix, rest, false) {
+		return name, false
+	}
+
+	rest = strings.TrimRight(rest, trailingPunct)
+	if rest == "" {
+		return name, false
+	}
+	return rest, true
+}
+
+// isBoundary проверяет, что префикс или суффикс не отрезает часть слова:
+// «Элитный» не должен срезаться с «Элитныйгоблин».
+func isBoundary(affix, rest string, prefix bool) bool {
+	if rest == "" {
+		return true
+	}
+
+	var affixEdge, restEdge rune
+	if prefix {
+		affixEdge = lastRune(affix)
+		restEdge = []rune(rest)[0]
+	} else {
+		affixEdge = []rune(affix)[0]
+		restEdge = lastRune(rest)
+	}
+
+	return !isWordRune(affixEdge) || !isWordRune(restEdge)
+}
+
+func lastRune(s string) rune {
+	r := []rune(s)
+	return r[len(r)-1]
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,95 @@
+package normalize
+
+import "testing"
+
+func TestNameBasicCleanup(t *testing.T) {
+	n := New(Options{})
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Часы", "Часы"},
+		{"  Часы  ", "Часы"},
+		{"Злая   шкатулка", "Злая шкатулка"},
+		{"Часы.", "Часы"},
+		{"Часы!", "Часы"},
+		{"[Элита] Часы", "Часы"},
+		{"Часы (чемпион)", "Часы"},
+		{"Ёжик", "Ежик"},
+		{"ёжик", "Ежик"},
+	}
+
+	for _, tt := range tests {
+		result := n.Name(tt.input)
+		if result != tt.expected {
+			t.Errorf("Name(%q): got %q, want %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestNameCaseFolding(t *testing.T) {
+	n := New(Options{})
+
+	first := n.Name("Луговая Жужа")
+	second := n.Name("луговая жужа")
+	third := n.Name("ЛУГОВАЯ ЖУЖА")
+
+	if first != "Луговая Жужа" || second != first || third != first {
+		t.Errorf("Case variants should fold to first seen form, got %q, %q, %q", first, second, third)
+	}
+}
+
+func TestNamePrefixesAndSuffixes(t *testing.T) {
+	n := New(Options{
+		Prefixes: []string{"Элитный", "Чемпион:"},
+		Suffixes: []string{"- босс"},
+	})
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Элитный Гоблин", "Гоблин"},
+		{"элитный Гоблин", "Гоблин"},
+		{"Чемпион: Гоблин", "Гоблин"},
+		{"Гоблин - босс", "Гоблин"},
+		{"Элитный Гоблин - босс.", "Гоблин"},
+		{"Элитныйгоблин", "Элитныйгоблин"},
+		{"Элитный", "Элитный"},
+	}
+
+	for _, tt := range tests {
+		result := n.Name(tt.input)
+		if result != tt.expected {
+			t.Errorf("Name(%q): got %q, want %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestNameAliases(t *testing.T) {
+	n := New(Options{
+		Aliases: map[string]string{
+			"Старые часы": "Часы",
+			"ЁЛКА":        "Ель",
+		},
+	})
+
+	if result := n.Name("Старые часы"); result != "Часы" {
+		t.Errorf("Alias: got %q, want %q", result, "Часы")
+	}
+	if result := n.Name("старые  часы."); result != "Часы" {
+		t.Errorf("Alias with cleanup: got %q, want %q", result, "Часы")
+	}
+	if result := n.Name("Елка"); result != "Ель" {
+		t.Errorf("Alias with ё: got %q, want %q", result, "Ель")
+	}
+}
+
+func TestNameEmptyAfterCleanup(t *testing.T) {
+	n := New(Options{})
+
+	if result := n.Name("[Элита]"); result != "[Элита]" {
+		t.Errorf("Name should fall back to raw value, got %q", result)
+	}
+}
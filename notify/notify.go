@@ -0,0 +1,161 @@
+package notify
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/parser"
+	"RQ_MobCounter/stats"
+)
+
+// Виды событий.
+const (
+	KindRare      = "rare"
+	KindMilestone = "milestone"
+	KindLevel     = "level"
+	KindSummary   = "summary"
+	KindGoal      = "goal"
+)
+
+var Kinds = []string{KindRare, KindMilestone, KindLevel, KindSummary, KindGoal}
+
+type Event struct {
+	Kind    string    `json:"event"`
+	Time    time.Time `json:"time"`
+	Monster string    `json:"monster,omitempty"`
+	Count   int       `json:"count,omitempty"`
+	Level   int       `json:"level,omitempty"`
+	Exp     int       `json:"exp,omitempty"`
+	Text    string    `json:"text"`
+}
+
+// Rules - при каких событиях отправлять уведомления.
+type Rules struct {
+	// Rare - редкие монстры и боссы, о каждом убийстве которых нужно
+	// сообщать. Имена сравниваются без учёта регистра.
+	Rare []string
+	// Milestones - количества убийств одного монстра, о достижении
+	// которых нужно сообщать.
+	Milestones []int
+	LevelUp    bool
+	Hourly     bool
+}
+
+// Tracker превращает поток записей лога в события. Имена монстров должны
+// быть уже нормализованы.
+type Tracker struct {
+	rules  Rules
+	rare   map[string]bool
+	counts map[string]int
+
+	hourStart time.Time
+	hourKills int
+	hourExp   int
+}
+
+func NewTracker(rules Rules) *Tracker {
+	t := &Tracker{
+		rules:  rules,
+		rare:   make(map[string]bool),
+		counts: make(map[string]int),
+	}
+	for _, name := range rules.Rare {
+		t.rare[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	return t
+}
+
+// Seed учитывает уже записанные убийства, не создавая событий, чтобы
+// вехи считались от начала месяца, а не от запуска.
+func (t *Tracker) Seed(entries []parser.LogEntry) {
+	for _, e := range entries {
+		if e.MonsterName != "" {
+			t.counts[e.MonsterName]++
+		}
+	}
+}
+
+// Reset сбрасывает счётчики, например при переходе на новый месяц.
+func (t *Tracker) Reset() {
+	t.counts = make(map[string]int)
+}
+
+// Observe возвращает события для новых записей и повышений уровня.
+func (t *Tracker) Observe(entries []parser.LogEntry, levels []parser.LevelUp) []Event {
+	var events []Event
+
+	for _, e := range entries {
+		if e.MonsterName == "" {
+			continue
+		}
+
+		t.counts[e.MonsterName]++
+		t.hourKills++
+		t.hourExp += e.ExpGained
+
+		if t.rare[strings.ToLower(e.MonsterName)] {
+			events = append(events, Event{
+				Kind:    KindRare,
+				Time:    e.Time,
+				Monster: e.MonsterName,
+				Exp:     e.ExpGained,
+				Text:    i18n.T("Убит редкий монстр: %s (+%s опыта)", e.MonsterName, stats.FormatNumberForDisplay(e.ExpGained)),
+			})
+		}
+
+		count := t.counts[e.MonsterName]
+		if slices.Contains(t.rules.Milestones, count) {
+			events = append(events, Event{
+				Kind:    KindMilestone,
+				Time:    e.Time,
+				Monster: e.MonsterName,
+				Count:   count,
+				Text:    i18n.T("%s: убито %s", e.MonsterName, stats.FormatNumberForDisplay(count)),
+			})
+		}
+	}
+
+	if t.rules.LevelUp {
+		for _, l := range levels {
+			events = append(events, Event{
+				Kind:  KindLevel,
+				Time:  l.Time,
+				Level: l.Level,
+				Text:  i18n.T("Достигнут %d уровень", l.Level),
+			})
+		}
+	}
+
+	return events
+}
+
+// Tick возвращает сводку за прошедший час, если он закончился и за него
+// были убийства. Первый вызов только запоминает начало часа.
+func (t *Tracker) Tick(now time.Time) []Event {
+	if !t.rules.Hourly {
+		return nil
+	}
+	if t.hourStart.IsZero() {
+		t.hourStart = now
+		return nil
+	}
+	if now.Sub(t.hourStart) < time.Hour {
+		return nil
+	}
+
+	kills, exp := t.hourKills, t.hourExp
+	t.hourStart, t.hourKills, t.hourExp = now, 0, 0
+	if kills == 0 {
+		return nil
+	}
+
+	return []Event{{
+		Kind:  KindSummary,
+		Time:  now,
+		Count: kills,
+		Exp:   exp,
+		Text:  i18n.T("За час: убийств %s, опыт %s", stats.FormatNumberForDisplay(kills), stats.FormatNumberForDisplay(exp)),
+	}}
+}
@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"RQ_MobCounter/parser"
+)
+
+func kill(name string, exp int, minute int) parser.LogEntry {
+	return parser.LogEntry{MonsterName: name, ExpGained: exp, Time: at(minute)}
+}
+
+func at(minute int) time.Time {
+	return time.Date(2026, 1, 16, 10, 0, 0, 0, time.Local).Add(time.Duration(minute) * time.Minute)
+}
+
+func kinds(events []Event) []string {
+	var result []string
+	for _, e := range events {
+		result = append(result, e.Kind)
+	}
+	return result
+}
+
+func TestTrackerRareAndMilestones(t *testing.T) {
+	tracker := NewTracker(Rules{Rare: []string{"Древний дракон"}, Milestones: []int{3, 5}})
+	tracker.Seed([]parser.LogEntry{kill("Часы", 10, 0)})
+
+	events := tracker.Observe([]parser.LogEntry{kill("Часы", 10, 1), kill("Древний дракон", 5000, 2)}, nil)
+	if len(events) != 1 || events[0].Kind != KindRare || events[0].Monster != "Древний дракон" {
+		t.Fatalf("Rare kill: got %+v", events)
+	}
+	if events[0].Text != "Убит редкий монстр: Древний дракон (+5 000 опыта)" {
+		t.Errorf("Rare text: got %q", events[0].Text)
+	}
+
+	// Третье убийство Часов с учётом записей до запуска
+	events = tracker.Observe([]parser.LogEntry{kill("Часы", 10, 3), kill("Часы", 10, 4)}, nil)
+	if len(events) != 1 || events[0].Kind != KindMilestone || events[0].Count != 3 || events[0].Text != "Часы: убито 3" {
+		t.Errorf("Milestone: got %+v", events)
+	}
+
+	tracker.Reset()
+	if events := tracker.Observe([]parser.LogEntry{kill("Часы", 10, 5)}, nil); len(events) != 0 {
+		t.Errorf("After reset: got %+v", events)
+	}
+}
+
+func TestTrackerLevelUp(t *testing.T) {
+	levels := []parser.LevelUp{{Level: 42, Time: at(0)}}
+
+	if events := NewTracker(Rules{}).Observe(nil, levels); len(events) != 0 {
+		t.Errorf("Level-ups disabled: got %+v", events)
+	}
+
+	events := NewTracker(Rules{LevelUp: true}).Observe(nil, levels)
+	if len(events) != 1 || events[0].Kind != KindLevel || events[0].Level != 42 {
+		t.Errorf("Level-up: got %+v", events)
+	}
+}
+
+func TestTrackerHourly(t *testing.T) {
+	tracker := NewTracker(Rules{Hourly: true})
+
+	if events := tracker.Tick(at(0)); len(events) != 0 {
+		t.Errorf("First tick: got %+v", events)
+	}
+	tracker.Observe([]parser.LogEntry{kill("Часы", 100, 5), kill("Росинка", 3, 10)}, nil)
+
+	if events := tracker.Tick(at(59)); len(events) != 0 {
+		t.Errorf("Tick before an hour: got %+v", events)
+	}
+
+	events := tracker.Tick(at(60))
+	if len(events) != 1 || events[0].Kind != KindSummary || events[0].Count != 2 || events[0].Exp != 103 {
+		t.Errorf("Hourly summary: got %+v", events)
+	}
+
+	// Час без убийств - сводка не нужна
+	if events := tracker.Tick(at(120)); len(events) != 0 {
+		t.Errorf("Empty hour: got %v", kinds(events))
+	}
+}
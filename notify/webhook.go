@@ -0,0 +1,155 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"RQ_MobCounter/i18n"
+)
+
+// Форматы тела запроса.
+const (
+	FormatJSON     = "json"
+	FormatDiscord  = "discord"
+	FormatTelegram = "telegram"
+)
+
+var Formats = []string{FormatJSON, FormatDiscord, FormatTelegram}
+
+const (
+	DefaultRetries = 3
+	DefaultBackoff = time.Second
+)
+
+type Webhook struct {
+	URL string
+	// Format - json (событие целиком), discord ({"content": ...}) или
+	// telegram ({"chat_id": ..., "text": ...}). По умолчанию json.
+	Format string
+	ChatID string
+	// Events - виды событий для этого адреса, пусто - все.
+	Events []string
+}
+
+func (w Webhook) wants(kind string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, kind)
+}
+
+// Payload возвращает тело запроса для события в формате вебхука.
+func Payload(w Webhook, e Event) ([]byte, error) {
+	switch w.Format {
+	case FormatDiscord:
+		return json.Marshal(map[string]string{"content": e.Text})
+	case FormatTelegram:
+		return json.Marshal(map[string]string{"chat_id": w.ChatID, "text": e.Text})
+	case FormatJSON, "":
+		return json.Marshal(e)
+	}
+	return nil, i18n.Errorf("неизвестный формат вебхука %q", w.Format)
+}
+
+// Notifier отправляет события на вебхуки. Неудачные запросы (ошибка сети,
+// 429 и 5xx) повторяются до Retries раз с удваивающейся паузой.
+type Notifier struct {
+	Webhooks []Webhook
+	Client   *http.Client
+	Retries  int
+	Backoff  time.Duration
+}
+
+func New(webhooks []Webhook) *Notifier {
+	return &Notifier{
+		Webhooks: webhooks,
+		Client:   &http.Client{Timeout: 10 * time.Second},
+		Retries:  DefaultRetries,
+		Backoff:  DefaultBackoff,
+	}
+}
+
+// Deliver отправляет событие на все вебхуки, подписанные на его вид.
+// Ошибка одного вебхука не мешает отправке на остальные.
+func (n *Notifier) Deliver(ctx context.Context, e Event) error {
+	var errs []error
+	for _, w := range n.Webhooks {
+		if !w.wants(e.Kind) {
+			continue
+		}
+		if err := n.send(ctx, w, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) send(ctx context.Context, w Webhook, e Event) error {
+	body, err := Payload(w, e)
+	if err != nil {
+		return err
+	}
+
+	backoff := n.Backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := n.post(ctx, w.URL, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= n.Retries {
+			return i18n.Errorf("ошибка отправки на %s: %w", redactURL(w.URL), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post отправляет запрос и сообщает, имеет ли смысл повторить его.
+func (n *Notifier) post(ctx context.Context, target string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return false, unwrapURLError(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := n.Client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, unwrapURLError(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+	retry := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+	return retry, fmt.Errorf("HTTP %s", res.Status)
+}
+
+// redactURL оставляет от адреса вебхука схему и хост: в пути и параметрах
+// обычно лежит токен (Discord, Telegram), которому не место в логах.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return i18n.T("(некорректный адрес)")
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// unwrapURLError убирает из *url.Error полный адрес запроса, оставляя
+// только причину ошибки.
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
@@ -0,0 +1,164 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder - тестовый вебхук, отвечающий кодами из statuses по очереди
+// (последний код повторяется) и запоминающий тела запросов.
+type recorder struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   []string
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bodies = append(r.bodies, string(body))
+	status := r.statuses[min(len(r.bodies), len(r.statuses))-1]
+	w.WriteHeader(status)
+}
+
+func newTestNotifier(webhooks ...Webhook) *Notifier {
+	n := New(webhooks)
+	n.Backoff = time.Millisecond
+	return n
+}
+
+func TestPayload(t *testing.T) {
+	e := Event{Kind: KindLevel, Level: 10, Text: "Достигнут 10 уровень"}
+
+	tests := []struct {
+		webhook  Webhook
+		expected string
+	}{
+		{Webhook{Format: FormatDiscord}, `{"content":"Достигнут 10 уровень"}`},
+		{Webhook{Format: FormatTelegram, ChatID: "-100"}, `{"chat_id":"-100","text":"Достигнут 10 уровень"}`},
+		{Webhook{}, `{"event":"level","time":"0001-01-01T00:00:00Z","level":10,"text":"Достигнут 10 уровень"}`},
+	}
+
+	for _, tt := range tests {
+		got, err := Payload(tt.webhook, e)
+		if err != nil {
+			t.Fatalf("Payload(%q) failed: %v", tt.webhook.Format, err)
+		}
+		if string(got) != tt.expected {
+			t.Errorf("Payload(%q): got %s, want %s", tt.webhook.Format, got, tt.expected)
+		}
+	}
+
+	if _, err := Payload(Webhook{Format: "slack"}, e); err == nil {
+		t.Error("Unknown format should fail")
+	}
+}
+
+func TestDeliverRetries(t *testing.T) {
+	hook := &recorder{statuses: []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusNoContent}}
+	server := httptest.NewServer(hook)
+	defer server.Close()
+
+	n := newTestNotifier(Webhook{URL: server.URL, Format: FormatDiscord})
+	if err := n.Deliver(context.Background(), Event{Kind: KindRare, Text: "Убит редкий монстр"}); err != nil {
+		t.Fatalf("Deliver failed: %v", err)
+	}
+
+	if len(hook.bodies) != 3 {
+		t.Fatalf("Attempts: got %d, want 3", len(hook.bodies))
+	}
+	var payload map[string]string
+	if err := json.Unmarshal([]byte(hook.bodies[2]), &payload); err != nil || payload["content"] != "Убит редкий монстр" {
+		t.Errorf("Body: got %q", hook.bodies[2])
+	}
+}
+
+func TestDeliverGivesUp(t *testing.T) {
+	failing := &recorder{statuses: []int{http.StatusBadGateway}}
+	server := httptest.NewServer(failing)
+	defer server.Close()
+
+	n := newTestNotifier(Webhook{URL: server.URL})
+	n.Retries = 2
+	if err := n.Deliver(context.Background(), Event{Kind: KindSummary}); err == nil {
+		t.Error("Deliver should fail when every attempt fails")
+	}
+	if len(failing.bodies) != 3 {
+		t.Errorf("Attempts: got %d, want 3 (1 + 2 retries)", len(failing.bodies))
+	}
+
+	// Ошибка клиента не исправится повтором
+	rejecting := &recorder{statuses: []int{http.StatusBadRequest}}
+	server = httptest.NewServer(rejecting)
+	defer server.Close()
+
+	n = newTestNotifier(Webhook{URL: server.URL})
+	if err := n.Deliver(context.Background(), Event{Kind: KindSummary}); err == nil {
+		t.Error("Deliver should fail on 400")
+	}
+	if len(rejecting.bodies) != 1 {
+		t.Errorf("400 should not be retried, got %d attempts", len(rejecting.bodies))
+	}
+}
+
+func TestDeliverFiltersEvents(t *testing.T) {
+	levels := &recorder{statuses: []int{http.StatusOK}}
+	all := &recorder{statuses: []int{http.StatusOK}}
+	levelServer := httptest.NewServer(levels)
+	defer levelServer.Close()
+	allServer := httptest.NewServer(all)
+	defer allServer.Close()
+
+	n := newTestNotifier(
+		Webhook{URL: levelServer.URL, Events: []string{KindLevel}},
+		Webhook{URL: allServer.URL},
+	)
+	n.Deliver(context.Background(), Event{Kind: KindRare})
+	n.Deliver(context.Background(), Event{Kind: KindLevel})
+
+	if len(levels.bodies) != 1 || len(all.bodies) != 2 {
+		t.Errorf("Subscriptions: level hook got %d, catch-all got %d", len(levels.bodies), len(all.bodies))
+	}
+}
+
+func TestDeliverHidesToken(t *testing.T) {
+	rejecting := &recorder{statuses: []int{http.StatusBadRequest}}
+	server := httptest.NewServer(rejecting)
+	defer server.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closedURL := closed.URL
+	closed.Close()
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"HTTP error", server.URL + "/bot123:secret/sendMessage?token=secret", server.URL},
+		{"Network error", closedURL + "/api/webhooks/1/secret", closedURL},
+		{"Invalid URL", "http://[::1/secret", "(некорректный адрес)"},
+	}
+
+	for _, tt := range tests {
+		n := newTestNotifier(Webhook{URL: tt.url})
+		n.Retries = 0
+		err := n.Deliver(context.Background(), Event{Kind: KindSummary})
+		if err == nil {
+			t.Errorf("%s: Deliver should fail", tt.name)
+			continue
+		}
+		if strings.Contains(err.Error(), "secret") || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got %q, want only %s", tt.name, err, tt.want)
+		}
+	}
+}
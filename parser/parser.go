@@ -1,47 +1,142 @@
 package parser
 
 import (
-	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+
+	"RQ_MobCounter/i18n"
 )
 
+const timestampLayout = "1/2 15:04:05"
+
 type LogEntry struct {
 	Timestamp   string
+	Time        time.Time
 	MonsterName string
 	ExpGained   int
+	Source      string
+	Line        int
+	Offset      int64
+	Raw         string
+	Character   string
+}
+
+// LevelUp - сообщение «Вы достигли N уровня!».
+type LevelUp struct {
+	Timestamp string
+	Time      time.Time
+	Level     int
+	Source    string
+	Line      int
 }
 
+// Document - результат разбора файла лога: записи об убийствах, повышения
+// уровня и общее количество строк таблицы (включая остальные сообщения).
+type Document struct {
+	Entries []LogEntry
+	Levels  []LevelUp
+	Rows    int
+}
+
+var levelRegex = regexp.MustCompile(`Вы достигли (\d+) уровня`)
+
 func ParseFile(filepath string) ([]LogEntry, error) {
-	data, err := os.ReadFile(filepath)
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка чтения файла: %w", err)
+	}
+	defer file.Close()
+
+	return Parse(file, filepath)
+}
+
+// Parse разбирает лог из r. source записывается в каждую запись вместе с
+// номером строки и смещением в байтах, чтобы можно было найти исходную строку.
+func Parse(r io.Reader, source string) ([]LogEntry, error) {
+	doc, err := ParseDocument(r, source)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Entries, nil
+}
+
+// ParseDocument работает как Parse, но дополнительно считает все строки лога.
+func ParseDocument(r io.Reader, source string) (Document, error) {
+	data, err := io.ReadAll(r)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка чтения файла: %w", err)
+		return Document{}, i18n.Errorf("ошибка чтения файла: %w", err)
 	}
 
 	content := string(data)
 	var entries []LogEntry
+	var levels []LevelUp
 
 	trRegex := regexp.MustCompile(`<TR[^>]*title='([^']+)'[^>]*><TD[^>]*>([^\n]+)`)
 
-	matches := trRegex.FindAllStringSubmatch(content, -1)
+	matches := trRegex.FindAllStringSubmatchIndex(content, -1)
+
+	line := 1
+	lineCounted := 0
 
 	for _, match := range matches {
-		if len(match) < 3 {
+		if len(match) < 6 {
 			continue
 		}
 
-		timestamp := match[1]
-		contentStr := match[2]
+		timestamp := content[match[2]:match[3]]
+		contentStr := content[match[4]:match[5]]
 
 		entry := parseLogEntry(timestamp, contentStr)
-		if entry != nil {
-			entries = append(entries, *entry)
+		level := 0
+		if entry == nil {
+			if level = parseLevel(contentStr); level == 0 {
+				continue
+			}
+		}
+
+		line += strings.Count(content[lineCounted:match[0]], "\n")
+		lineCounted = match[0]
+
+		if entry == nil {
+			levels = append(levels, LevelUp{Timestamp: timestamp, Level: level, Source: source, Line: line})
+			continue
 		}
+
+		entry.Source = source
+		entry.Line = line
+		entry.Offset = int64(match[0])
+		entry.Raw = content[match[0]:match[1]]
+		entries = append(entries, *entry)
 	}
 
-	return entries, nil
+	return Document{Entries: entries, Levels: levels, Rows: len(matches)}, nil
+}
+
+// ParseTimestamp переводит время из лога («1/16 06:45:41») в time.Time.
+// Год в логе не пишется, поэтому он передаётся отдельно (из имени файла).
+func ParseTimestamp(timestamp string, year int) (time.Time, error) {
+	t, err := time.ParseInLocation(timestampLayout, strings.TrimSpace(timestamp), time.Local)
+	if err != nil {
+		return time.Time{}, i18n.Errorf("некорректное время %q: %w", timestamp, err)
+	}
+	return time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local), nil
+}
+
+// parseLevel возвращает уровень из сообщения о повышении уровня или 0.
+func parseLevel(content string) int {
+	match := levelRegex.FindStringSubmatch(content)
+	if match == nil {
+		return 0
+	}
+	level, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+	return level
 }
 
 func parseLogEntry(timestamp string, content string) *LogEntry {
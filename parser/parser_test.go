@@ -2,7 +2,9 @@ package parser
 
 import (
 	"os"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseLogEntry(t *testing.T) {
@@ -198,6 +200,82 @@ func TestExpExtraction(t *testing.T) {
 	}
 }
 
+func TestParseReader(t *testing.T) {
+	htmlContent := `<TABLE><TR style='color:#4A92D3' valign=top title='1/16 06:45:41'><TD colspan=2>Злая шкатулка погибает. Получено опыта: 2873.
+<TR style='color:#4A92D3' valign=top title='1/16 06:58:30'><TD colspan=2>Вы достигли 2 уровня!
+</TABLE>`
+
+	entries, err := Parse(strings.NewReader(htmlContent), "test.htm")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	if entries[0].MonsterName != "Злая шкатулка" || entries[0].ExpGained != 2873 {
+		t.Errorf("Unexpected entry: %+v", entries[0])
+	}
+}
+
+func TestParseProvenance(t *testing.T) {
+	first := "<TR style='color:#4A92D3' valign=top title='1/16 06:45:41'><TD colspan=2>Злая шкатулка погибает. Получено опыта: 2873."
+	second := "<TR style='color:#4A92D3' valign=top title='1/16 06:45:53'><TD colspan=2>Часы погибает. Получено опыта: 17530."
+	header := "<HTML>\n<BODY>\n<TABLE>"
+	htmlContent := header + first + "\n" +
+		"<TR style='color:#4A92D3' valign=top title='1/16 06:58:30'><TD colspan=2>Вы достигли 2 уровня!\n" +
+		second + "\n</TABLE>"
+
+	entries, err := Parse(strings.NewReader(htmlContent), "logs/exp (2026.01).htm")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	tests := []struct {
+		line   int
+		offset int64
+		raw    string
+	}{
+		{3, int64(len(header)), first},
+		{5, int64(strings.Index(htmlContent, second)), second},
+	}
+
+	for i, tt := range tests {
+		entry := entries[i]
+		if entry.Source != "logs/exp (2026.01).htm" {
+			t.Errorf("Entry %d source: got %q", i, entry.Source)
+		}
+		if entry.Line != tt.line {
+			t.Errorf("Entry %d line: got %d, want %d", i, entry.Line, tt.line)
+		}
+		if entry.Offset != tt.offset {
+			t.Errorf("Entry %d offset: got %d, want %d", i, entry.Offset, tt.offset)
+		}
+		if entry.Raw != tt.raw {
+			t.Errorf("Entry %d raw: got %q, want %q", i, entry.Raw, tt.raw)
+		}
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	result, err := ParseTimestamp("1/16 06:45:41", 2026)
+	if err != nil {
+		t.Fatalf("ParseTimestamp failed: %v", err)
+	}
+
+	expected := time.Date(2026, 1, 16, 6, 45, 41, 0, time.Local)
+	if !result.Equal(expected) {
+		t.Errorf("ParseTimestamp: got %v, want %v", result, expected)
+	}
+
+	if _, err := ParseTimestamp("вчера", 2026); err == nil {
+		t.Errorf("ParseTimestamp should fail for invalid timestamp")
+	}
+}
+
 // Helper functions for testing
 func writeTempFile(filename, content string) error {
 	return os.WriteFile(filename, []byte(content), 0644)
@@ -206,3 +284,25 @@ func writeTempFile(filename, content string) error {
 func deleteTempFile(filename string) error {
 	return os.Remove(filename)
 }
+
+func TestParseDocumentRows(t *testing.T) {
+	htmlContent := `<TABLE><TR style='color:#4A92D3' valign=top title='1/16 06:45:41'><TD colspan=2>Злая шкатулка погибает. Получено опыта: 2873.
+<TR style='color:#4A92D3' valign=top title='1/16 06:58:30'><TD colspan=2>Вы достигли 2 уровня!
+<TR style='color:#4A92D3' valign=top title='1/16 07:00:00'><TD colspan=2>Получено 10 золота.
+</TABLE>`
+
+	doc, err := ParseDocument(strings.NewReader(htmlContent), "test.htm")
+	if err != nil {
+		t.Fatalf("ParseDocument failed: %v", err)
+	}
+
+	if doc.Rows != 3 {
+		t.Errorf("Rows: got %d, want 3", doc.Rows)
+	}
+	if len(doc.Entries) != 1 {
+		t.Errorf("Entries: got %d, want 1", len(doc.Entries))
+	}
+	if len(doc.Levels) != 1 || doc.Levels[0].Level != 2 || doc.Levels[0].Line != 2 || doc.Levels[0].Timestamp != "1/16 06:58:30" {
+		t.Errorf("Levels: got %+v", doc.Levels)
+	}
+}
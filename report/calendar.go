@@ -0,0 +1,120 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/stats"
+)
+
+// Размеры календаря в пикселях.
+const (
+	cellSize   = 11
+	cellStep   = 13
+	leftMargin = 28
+	topMargin  = 20
+	// legendWidth - ширина легенды «Меньше ... Больше»
+	legendWidth = 160
+)
+
+// CalendarColors - цвета ячеек от дня без убийств до самого активного.
+var CalendarColors = []string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+
+var monthNames = []string{
+	i18n.N("янв"), i18n.N("фев"), i18n.N("мар"), i18n.N("апр"), i18n.N("май"), i18n.N("июн"),
+	i18n.N("июл"), i18n.N("авг"), i18n.N("сен"), i18n.N("окт"), i18n.N("ноя"), i18n.N("дек"),
+}
+
+// Calendar рисует календарь активности в духе GitHub: столбец на неделю
+// (с понедельника), ячейка на день, цвет - по убийствам или, если exp, по
+// опыту. Календарь охватывает месяцы от первого до последнего дня в days
+// целиком; в подсказке ячейки - итоги дня.
+func Calendar(w io.Writer, days []stats.DayStats, exp bool) error {
+	if len(days) == 0 {
+		return i18n.Errorf("нет данных для календаря")
+	}
+
+	totals := make(map[string]stats.DayStats, len(days))
+	peak := 0
+	for _, d := range days {
+		totals[d.Date.Format(time.DateOnly)] = d
+		peak = max(peak, dayValue(d, exp))
+	}
+
+	first, last := days[0].Date, days[len(days)-1].Date
+	start := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(last.Year(), last.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	// Первый столбец начинается с понедельника
+	gridStart := start.AddDate(0, 0, -weekday(start))
+	weeks := (int(end.Sub(gridStart).Hours()/24) + 6) / 7
+
+	// Узкий календарь за один месяц расширяется, чтобы поместилась легенда
+	width := max(leftMargin+weeks*cellStep+cellStep, leftMargin+legendWidth)
+	height := topMargin + 7*cellStep + 2*cellStep
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="9">`+"\n", width, height, width, height)
+	b.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>` + "\n")
+
+	for m := start; m.Before(end); m = m.AddDate(0, 1, 0) {
+		x := leftMargin + int(m.Sub(gridStart).Hours()/24)/7*cellStep
+		label := i18n.T(monthNames[m.Month()-1])
+		if m.Month() == time.January || m.Equal(start) {
+			label += fmt.Sprintf(" %d", m.Year())
+		}
+		fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#767676">%s</text>`+"\n", x, topMargin-6, escape(label))
+	}
+
+	names := stats.WeekdayNames()
+	for _, d := range []int{0, 2, 4} {
+		fmt.Fprintf(&b, `<text x="0" y="%d" fill="#767676">%s</text>`+"\n", topMargin+d*cellStep+cellSize-2, escape(names[d]))
+	}
+
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		offset := int(day.Sub(gridStart).Hours() / 24)
+		x := leftMargin + offset/7*cellStep
+		y := topMargin + offset%7*cellStep
+
+		key := day.Format(time.DateOnly)
+		total := totals[key]
+		color := CalendarColors[stats.ShadeLevel(dayValue(total, exp), peak, len(CalendarColors)-1)]
+		title := i18n.T("%s: убийств %s, опыт %s", key, stats.FormatNumberForDisplay(total.KillCount), stats.FormatNumberForDisplay(total.TotalExp))
+
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%s</title></rect>`+"\n",
+			x, y, cellSize, cellSize, color, escape(title))
+	}
+
+	legendY := topMargin + 7*cellStep + cellStep/2
+	x := leftMargin + 45
+	fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#767676">%s</text>`+"\n", leftMargin, legendY+cellSize-2, escape(i18n.T("Меньше")))
+	for i, color := range CalendarColors {
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"/>`+"\n", x+i*cellStep, legendY, cellSize, cellSize, color)
+	}
+	fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#767676">%s</text>`+"\n", x+len(CalendarColors)*cellStep+2, legendY+cellSize-2, escape(i18n.T("Больше")))
+
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func dayValue(d stats.DayStats, exp bool) int {
+	if exp {
+		return d.TotalExp
+	}
+	return d.KillCount
+}
+
+// weekday - номер дня недели с понедельника.
+func weekday(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"RQ_MobCounter/stats"
+)
+
+// go test ./report -update перезаписывает эталонные файлы в testdata.
+var update = flag.Bool("update", false, "перезаписать эталонные файлы")
+
+func day(month time.Month, d, kills, exp int) stats.DayStats {
+	return stats.DayStats{Date: time.Date(2026, month, d, 0, 0, 0, 0, time.Local), KillCount: kills, TotalExp: exp}
+}
+
+// golden сравнивает got с файлом testdata/name.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (запустите go test ./report -update)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from golden file, got:\n%s", name, got)
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	days := []stats.DayStats{
+		day(time.January, 16, 3, 37936),
+		day(time.January, 17, 1, 3),
+		day(time.February, 2, 12, 150000),
+		day(time.February, 28, 6, 1200),
+	}
+
+	var kills bytes.Buffer
+	if err := Calendar(&kills, days, false); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "calendar.svg", kills.Bytes())
+
+	var exp bytes.Buffer
+	if err := Calendar(&exp, days, true); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "calendar_exp.svg", exp.Bytes())
+
+	// Подсказка у дня без убийств тоже есть
+	if !strings.Contains(kills.String(), "<title>2026-01-01: убийств 0, опыт 0</title>") {
+		t.Errorf("Empty day title missing")
+	}
+
+	if err := Calendar(&bytes.Buffer{}, nil, false); err == nil {
+		t.Errorf("Empty calendar: expected error")
+	}
+}
@@ -0,0 +1,323 @@
+package report
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/stats"
+)
+
+//go:embed report.html
+var htmlSource string
+
+var htmlTemplate = template.Must(template.New("report").Parse(htmlSource))
+
+// TopMonsters - сколько монстров показывать на графике.
+const TopMonsters = 10
+
+// Размеры графиков HTML-отчёта в пикселях.
+const (
+	chartWidth  = 760
+	chartHeight = 220
+	chartLeft   = 70
+	chartBottom = 24
+	barHeight   = 18
+	rowHeight   = 14
+)
+
+// Report - данные HTML-отчёта. Monsters - статистика по монстрам в
+// порядке вывода, как в rqmc stats; Days и Sessions - в порядке времени.
+type Report struct {
+	Generated time.Time
+	Monsters  []stats.MonsterStats
+	Days      []stats.DayStats
+	Sessions  []stats.Session
+}
+
+type htmlLabels struct {
+	Title, Generated, Kills, Exp, Days, DayTable, Sessions        string
+	ExpChart, MonsterChart, Timeline, Calendar                    string
+	Monsters, Monster, Count, TotalExp, AvgExp, Date              string
+	Start, Duration, ExpPerHour, TopMonster, SortHint, NoSessions string
+}
+
+type htmlMonster struct {
+	Name               string
+	Count, Exp, Avg    int
+	CountS, ExpS, AvgS string
+}
+
+type htmlDay struct {
+	Date         string
+	Kills, Exp   int
+	KillsS, ExpS string
+}
+
+type htmlSession struct {
+	Start, Duration, TopMonster string
+	Minutes                     int64
+	Kills, Exp, Rate            int
+	KillsS, ExpS, RateS         string
+}
+
+type htmlPage struct {
+	Lang, Period, Generated                    string
+	L                                          htmlLabels
+	Kills, Exp, DayCount, SessionCount         string
+	ExpChart, MonsterChart, Timeline, Calendar template.HTML
+	Monsters                                   []htmlMonster
+	Days                                       []htmlDay
+	Sessions                                   []htmlSession
+}
+
+// HTML сохраняет отчёт одной HTML-страницей без внешних ресурсов: стили и
+// скрипт сортировки таблиц встроены, графики - SVG внутри страницы.
+func HTML(w io.Writer, r Report) error {
+	if len(r.Days) == 0 {
+		return i18n.Errorf("нет данных для отчёта")
+	}
+
+	page := htmlPage{
+		Lang:      i18n.Language(),
+		Period:    r.Days[0].Date.Format(time.DateOnly) + " - " + r.Days[len(r.Days)-1].Date.Format(time.DateOnly),
+		Generated: r.Generated.Format("2006-01-02 15:04"),
+		L: htmlLabels{
+			Title:        i18n.T("Отчёт RQ MobCounter"),
+			Generated:    i18n.T("Сформирован"),
+			Kills:        i18n.T("Убийств"),
+			Exp:          i18n.T("Опыт"),
+			Days:         i18n.T("Дней"),
+			DayTable:     i18n.T("По дням"),
+			Sessions:     i18n.T("Сессии"),
+			ExpChart:     i18n.T("Опыт по дням"),
+			MonsterChart: i18n.T("Самые частые монстры"),
+			Timeline:     i18n.T("Сессии по времени суток"),
+			Calendar:     i18n.T("Календарь активности"),
+			Monsters:     i18n.T("Монстры"),
+			Monster:      i18n.T("Монстр"),
+			Count:        i18n.T("Количество"),
+			TotalExp:     i18n.T("Суммарный опыт"),
+			AvgExp:       i18n.T("Средний опыт"),
+			Date:         i18n.T("Дата"),
+			Start:        i18n.T("Начало"),
+			Duration:     i18n.T("Длит."),
+			ExpPerHour:   i18n.T("Опыт/час"),
+			TopMonster:   i18n.T("Чаще всего"),
+			SortHint:     i18n.T("Нажмите на заголовок столбца, чтобы отсортировать таблицу"),
+			NoSessions:   i18n.T("Нет сессий со временем"),
+		},
+		SessionCount: stats.FormatNumberForDisplay(len(r.Sessions)),
+		DayCount:     stats.FormatNumberForDisplay(len(r.Days)),
+	}
+
+	kills, exp := 0, 0
+	for _, d := range r.Days {
+		kills += d.KillCount
+		exp += d.TotalExp
+		page.Days = append(page.Days, htmlDay{
+			Date:   d.Date.Format(time.DateOnly),
+			Kills:  d.KillCount,
+			Exp:    d.TotalExp,
+			KillsS: stats.FormatNumberForDisplay(d.KillCount),
+			ExpS:   stats.FormatNumberForDisplay(d.TotalExp),
+		})
+	}
+	page.Kills = stats.FormatNumberForDisplay(kills)
+	page.Exp = stats.FormatNumberForDisplay(exp)
+
+	for _, m := range r.Monsters {
+		page.Monsters = append(page.Monsters, htmlMonster{
+			Name:   m.Name,
+			Count:  m.KillCount,
+			Exp:    m.TotalExp,
+			Avg:    m.AvgExp(),
+			CountS: stats.FormatNumberForDisplay(m.KillCount),
+			ExpS:   stats.FormatNumberForDisplay(m.TotalExp),
+			AvgS:   stats.FormatNumberForDisplay(m.AvgExp()),
+		})
+	}
+
+	for _, s := range r.Sessions {
+		page.Sessions = append(page.Sessions, htmlSession{
+			Start:      s.Start.Format("2006-01-02 15:04"),
+			Duration:   stats.FormatDuration(s.Duration()),
+			TopMonster: s.TopMonster,
+			Minutes:    int64(s.Duration() / time.Minute),
+			Kills:      s.KillCount,
+			Exp:        s.TotalExp,
+			Rate:       s.ExpPerHour(),
+			KillsS:     stats.FormatNumberForDisplay(s.KillCount),
+			ExpS:       stats.FormatNumberForDisplay(s.TotalExp),
+			RateS:      stats.FormatNumberForDisplay(s.ExpPerHour()),
+		})
+	}
+
+	var calendar bytes.Buffer
+	if err := Calendar(&calendar, r.Days, false); err != nil {
+		return err
+	}
+	// Графики собираются здесь же и вставляются в шаблон как есть: текст
+	// внутри них экранирован через escape
+	page.Calendar = template.HTML(calendar.String())
+	page.ExpChart = template.HTML(expChart(r.Days))
+	page.MonsterChart = template.HTML(monsterChart(r.Monsters))
+	if len(r.Sessions) > 0 {
+		page.Timeline = template.HTML(sessionTimeline(r.Sessions))
+	}
+
+	return htmlTemplate.Execute(w, page)
+}
+
+// expChart - столбцы опыта по дням с подписями первого, последнего и
+// каждого седьмого дня. Дни без убийств остаются пустыми местами на оси.
+func expChart(daily []stats.DayStats) string {
+	var days []stats.DayStats
+	for _, d := range daily {
+		if len(days) > 0 {
+			for gap := days[len(days)-1].Date.AddDate(0, 0, 1); gap.Before(d.Date); gap = gap.AddDate(0, 0, 1) {
+				days = append(days, stats.DayStats{Date: gap})
+			}
+		}
+		days = append(days, d)
+	}
+
+	peak := 1
+	for _, d := range days {
+		peak = max(peak, d.TotalExp)
+	}
+
+	plotWidth := chartWidth - chartLeft - 10
+	plotHeight := chartHeight - chartBottom - 10
+	step := float64(plotWidth) / float64(len(days))
+	barWidth := max(step*0.8, 1)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" class="chart" viewBox="0 0 %d %d" font-size="10">`+"\n", chartWidth, chartHeight)
+	axis(&b, chartLeft, 10, plotWidth, plotHeight, peak)
+
+	for i, d := range days {
+		h := float64(plotHeight) * float64(d.TotalExp) / float64(peak)
+		x := float64(chartLeft) + float64(i)*step + (step-barWidth)/2
+		y := float64(10+plotHeight) - h
+		date := d.Date.Format(time.DateOnly)
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="#40c463"><title>%s</title></rect>`+"\n",
+			x, y, barWidth, h, escape(i18n.T("%s: убийств %s, опыт %s", date, stats.FormatNumberForDisplay(d.KillCount), stats.FormatNumberForDisplay(d.TotalExp))))
+		if i == 0 || i == len(days)-1 || i%7 == 0 {
+			fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle" fill="#767676">%s</text>`+"\n",
+				x+barWidth/2, chartHeight-8, d.Date.Format("01-02"))
+		}
+	}
+
+	b.WriteString("</svg>")
+	return b.String()
+}
+
+// monsterChart - горизонтальные столбцы убийств для TopMonsters монстров с
+// наибольшим количеством.
+func monsterChart(monsters []stats.MonsterStats) string {
+	top := append([]stats.MonsterStats(nil), monsters...)
+	stats.SortMonsters(top, "count")
+	if len(top) > TopMonsters {
+		top = top[:TopMonsters]
+	}
+
+	peak := 1
+	for _, m := range top {
+		peak = max(peak, m.KillCount)
+	}
+
+	const labelWidth = 220
+	barSpace := chartWidth - labelWidth - 80
+	height := max(len(top), 1)*(barHeight+6) + 6
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" class="chart" viewBox="0 0 %d %d" font-size="12">`+"\n", chartWidth, height)
+	for i, m := range top {
+		y := 6 + i*(barHeight+6)
+		w := max(barSpace*m.KillCount/peak, 1)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end" fill="#24292f">%s</text>`+"\n",
+			labelWidth-8, y+barHeight-5, escape(truncate(m.Name, 32)))
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="#30a14e"><title>%s</title></rect>`+"\n",
+			labelWidth, y, w, barHeight, escape(i18n.T("%s: убийств %s, опыт %s", m.Name, stats.FormatNumberForDisplay(m.KillCount), stats.FormatNumberForDisplay(m.TotalExp))))
+		fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#767676">%s</text>`+"\n",
+			labelWidth+w+6, y+barHeight-5, stats.FormatNumberForDisplay(m.KillCount))
+	}
+	b.WriteString("</svg>")
+	return b.String()
+}
+
+// sessionTimeline - строка на каждый день от первой до последней сессии,
+// сессии отложены по времени суток. Сессия через полночь продолжается в
+// строке следующего дня.
+func sessionTimeline(sessions []stats.Session) string {
+	first := dayStart(sessions[0].Start)
+	last := dayStart(sessions[len(sessions)-1].End)
+	rows := int(last.Sub(first).Hours()/24+0.5) + 1
+
+	plotWidth := chartWidth - chartLeft - 10
+	height := rows*rowHeight + chartBottom + 4
+	hourWidth := float64(plotWidth) / 24
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" class="chart" viewBox="0 0 %d %d" font-size="10">`+"\n", chartWidth, height)
+	for h := 0; h <= 24; h += 3 {
+		x := float64(chartLeft) + float64(h)*hourWidth
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="4" x2="%.1f" y2="%d" stroke="#eaeef2"/>`+"\n", x, x, 4+rows*rowHeight)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle" fill="#767676">%02d:00</text>`+"\n", x, height-8, h)
+	}
+	for i := range rows {
+		day := first.AddDate(0, 0, i)
+		fmt.Fprintf(&b, `<text x="0" y="%d" fill="#767676">%s</text>`+"\n", 4+i*rowHeight+rowHeight-4, day.Format("01-02"))
+	}
+
+	for _, s := range sessions {
+		title := escape(i18n.T("%s, %s: убийств %s, опыт %s", s.Start.Format("2006-01-02 15:04"),
+			stats.FormatDuration(s.Duration()), stats.FormatNumberForDisplay(s.KillCount), stats.FormatNumberForDisplay(s.TotalExp)))
+		for start := s.Start; !start.After(s.End); {
+			day := dayStart(start)
+			end := s.End
+			if next := day.AddDate(0, 0, 1); !end.Before(next) {
+				end = next
+			}
+			row := int(day.Sub(first).Hours()/24 + 0.5)
+			x := float64(chartLeft) + start.Sub(day).Hours()*hourWidth
+			w := max(end.Sub(start).Hours()*hourWidth, 2)
+			fmt.Fprintf(&b, `<rect x="%.1f" y="%d" width="%.1f" height="%d" rx="2" fill="#216e39"><title>%s</title></rect>`+"\n",
+				x, 4+row*rowHeight+2, w, rowHeight-4, title)
+			if end.Equal(s.End) {
+				break
+			}
+			start = end
+		}
+	}
+	b.WriteString("</svg>")
+	return b.String()
+}
+
+// axis рисует вертикальную ось с подписями 0, половины и максимума.
+func axis(b *strings.Builder, left, top, width, height, peak int) {
+	for _, part := range []int{0, 1, 2} {
+		y := top + height - height*part/2
+		fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#eaeef2"/>`+"\n", left, y, left+width, y)
+		fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="end" fill="#767676">%s</text>`+"\n",
+			left-6, y+3, stats.FormatNumberForDisplay(peak*part/2))
+	}
+}
+
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,54 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"RQ_MobCounter/stats"
+)
+
+func TestHTML(t *testing.T) {
+	at := func(d, hour, minute int) time.Time {
+		return time.Date(2026, time.January, d, hour, minute, 0, 0, time.Local)
+	}
+	r := Report{
+		Generated: time.Date(2026, time.February, 1, 12, 0, 0, 0, time.Local),
+		Monsters: []stats.MonsterStats{
+			{Name: "Часы", KillCount: 3, TotalExp: 35160},
+			{Name: "<Злая> шкатулка & Ко", KillCount: 1, TotalExp: 2873},
+		},
+		Days: []stats.DayStats{
+			day(time.January, 16, 3, 37936),
+			day(time.January, 18, 1, 100),
+		},
+		Sessions: []stats.Session{
+			{Start: at(16, 6, 45), End: at(16, 7, 30), KillCount: 3, TotalExp: 37936, TopMonster: "Часы"},
+			// Сессия через полночь рисуется двумя полосами
+			{Start: at(17, 23, 30), End: at(18, 0, 30), KillCount: 1, TotalExp: 100, TopMonster: "<Злая> шкатулка & Ко"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := HTML(&buf, r); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "report.html", buf.Bytes())
+
+	page := buf.String()
+	if strings.Contains(page, "<Злая>") || !strings.Contains(page, "&lt;Злая&gt; шкатулка &amp; Ко") {
+		t.Errorf("Monster name is not escaped")
+	}
+	if n := strings.Count(page, "<title>2026-01-17 23:30, 1:00: убийств 1, опыт 100</title>"); n != 2 {
+		t.Errorf("Midnight session: got %d bars, want 2", n)
+	}
+	// День без убийств между 16 и 18 января есть на графике опыта
+	if !strings.Contains(page, `fill="#40c463"><title>2026-01-17: убийств 0, опыт 0</title>`) {
+		t.Errorf("Empty day missing from exp chart")
+	}
+
+	if err := HTML(&bytes.Buffer{}, Report{}); err == nil {
+		t.Errorf("Empty report: expected error")
+	}
+}
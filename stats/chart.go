@@ -0,0 +1,237 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/i18n"
+)
+
+// Колонки-графики таблицы монстров.
+const (
+	ChartCount = "count"
+	ChartExp   = "exp"
+)
+
+var ChartValues = []string{ChartCount, ChartExp}
+
+var chartHeaders = map[string]string{
+	ChartCount: i18n.N("Убийства"),
+	ChartExp:   i18n.N("Опыт"),
+}
+
+// Символы полос: восьмые доли клетки в Unicode, целые клетки в ASCII.
+var (
+	unicodeBar    = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉", "█"}
+	unicodeSpark  = []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+	asciiSpark    = []string{" ", ".", ":", "-", "=", "+", "*", "#", "%"}
+	asciiBarBlock = "#"
+)
+
+// Ширина колонок-графиков, когда ширина консоли неизвестна или мала.
+const (
+	defaultBarWidth   = 30
+	minBarWidth       = 10
+	maxSparkWidth     = 62
+	minSparkWidth     = 7
+	minNameWidth      = 10
+	columnSeparatorSz = 3
+)
+
+// KillsPerDay считает убийства каждого монстра по дням от первого до
+// последнего дня с записями, с учётом нормализации и фильтра. Записи без
+// времени не учитываются.
+func (c *Calculator) KillsPerDay() map[string][]int {
+	var first, last time.Time
+	for _, e := range c.entries {
+		if e.MonsterName == "" || e.Time.IsZero() {
+			continue
+		}
+		if first.IsZero() || e.Time.Before(first) {
+			first = e.Time
+		}
+		if e.Time.After(last) {
+			last = e.Time
+		}
+	}
+	if first.IsZero() {
+		return nil
+	}
+
+	start := dayStart(first)
+	days := dayIndex(start, last) + 1
+
+	result := make(map[string][]int)
+	for _, e := range c.entries {
+		if e.MonsterName == "" || e.Time.IsZero() {
+			continue
+		}
+		name := e.MonsterName
+		if c.normalize != nil {
+			name = c.normalize(name)
+		}
+		if c.filter != "" && !strings.Contains(strings.ToLower(name), c.filter) {
+			continue
+		}
+		if result[name] == nil {
+			result[name] = make([]int, days)
+		}
+		result[name][dayIndex(start, e.Time)]++
+	}
+	return result
+}
+
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// dayIndex - номер дня t от start по календарю, а не по 24 часам, чтобы
+// переход на летнее время не сдвигал дни.
+func dayIndex(start, t time.Time) int {
+	day := dayStart(t)
+	a := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// Bar рисует полосу длиной value/peak от width клеток. В Unicode длина
+// точна до восьмой доли клетки, ненулевое значение видно всегда.
+func Bar(value, peak, width int, ascii bool) string {
+	if value <= 0 || peak <= 0 || width <= 0 {
+		return ""
+	}
+	if ascii {
+		n := max(value*width/peak, 1)
+		return strings.Repeat(asciiBarBlock, n)
+	}
+
+	eighths := max(value*width*8/peak, 1)
+	return strings.Repeat(unicodeBar[8], eighths/8) + unicodeBar[eighths%8]
+}
+
+// Sparkline рисует значения столбиками высотой до восьми уровней. Если
+// значений больше width, соседние складываются, чтобы строка поместилась.
+func Sparkline(values []int, width int, ascii bool) string {
+	if width <= 0 || len(values) == 0 {
+		return ""
+	}
+	values = bucket(values, width)
+
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	levels := unicodeSpark
+	if ascii {
+		levels = asciiSpark
+	}
+	var b strings.Builder
+	for _, v := range values {
+		b.WriteString(levels[ShadeLevel(v, peak, len(levels)-1)])
+	}
+	return b.String()
+}
+
+// bucket сворачивает values в width групп почти одинаковой длины.
+func bucket(values []int, width int) []int {
+	if len(values) <= width {
+		return values
+	}
+	result := make([]int, width)
+	for i, v := range values {
+		result[i*width/len(values)] += v
+	}
+	return result
+}
+
+// chartWidths делит свободное место консоли между полосой и спарклайном.
+// tableWidth - ширина остальных колонок; days - длина спарклайна без сжатия.
+func chartWidths(opts TableOptions, tableWidth, days int) (bar, spark int) {
+	showBar := opts.Chart != ""
+	showSpark := opts.Sparklines != nil
+
+	if showSpark {
+		spark = max(min(days, maxSparkWidth), minSparkWidth)
+	}
+	if showBar {
+		bar = defaultBarWidth
+	}
+	if opts.Width <= 0 {
+		return bar, spark
+	}
+
+	free := opts.Width - tableWidth
+	if showBar {
+		free -= columnSeparatorSz
+	}
+	if showSpark {
+		free -= columnSeparatorSz
+	}
+
+	switch {
+	case showBar && showSpark:
+		spark = max(min(spark, free/2), minSparkWidth)
+		bar = max(free-spark, minBarWidth)
+	case showBar:
+		bar = max(free, minBarWidth)
+	case showSpark:
+		spark = max(min(spark, free), minSparkWidth)
+	}
+	return bar, spark
+}
+
+// fitChart возвращает ширину колонки имён и графиков. Если графики даже в
+// минимальную ширину не помещаются в opts.Width, сначала укорачиваются
+// имена (names - их ширина, 0 без колонки имён), затем сами графики.
+func fitChart(opts TableOptions, tableWidth, names, days int) (name, bar, spark int) {
+	bar, spark = chartWidths(opts, tableWidth, days)
+	over := tableWidth + chartsWidth(bar, spark) - opts.Width
+	if opts.Width <= 0 || over <= 0 {
+		return names, bar, spark
+	}
+
+	cut := max(min(over, names-minNameWidth), 0)
+	names -= cut
+	tableWidth -= cut
+	bar, spark = chartWidths(opts, tableWidth, days)
+
+	over = tableWidth + chartsWidth(bar, spark) - opts.Width
+	if over > 0 && bar > 0 {
+		cut = min(over, bar-1)
+		bar -= cut
+		over -= cut
+	}
+	if over > 0 && spark > 0 {
+		spark -= min(over, spark-1)
+	}
+	return names, bar, spark
+}
+
+// chartsWidth - ширина колонок-графиков вместе с разделителями.
+func chartsWidth(bar, spark int) int {
+	width := 0
+	if bar > 0 {
+		width += bar + columnSeparatorSz
+	}
+	if spark > 0 {
+		width += spark + columnSeparatorSz
+	}
+	return width
+}
+
+func chartValue(s MonsterStats, chart string) int {
+	if chart == ChartExp {
+		return s.TotalExp
+	}
+	return s.KillCount
+}
+
+// padChart дополняет колонку пробелами, только если за ней есть другая.
+func padChart(text string, width int, pad bool) string {
+	if !pad {
+		return text
+	}
+	return fmt.Sprintf("%-*s", width, text)
+}
@@ -0,0 +1,147 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"RQ_MobCounter/parser"
+)
+
+func TestBar(t *testing.T) {
+	tests := []struct {
+		value, peak, width int
+		ascii              bool
+		expected           string
+	}{
+		{10, 10, 4, false, "████"},
+		{5, 10, 4, false, "██"},
+		{3, 10, 4, false, "█▏"},
+		{7, 10, 4, false, "██▊"},
+		// Ненулевое значение видно даже рядом с огромным максимумом
+		{1, 1000, 4, false, "▏"},
+		{0, 10, 4, false, ""},
+		{10, 10, 4, true, "####"},
+		{1, 1000, 4, true, "#"},
+	}
+
+	for _, tt := range tests {
+		if got := Bar(tt.value, tt.peak, tt.width, tt.ascii); got != tt.expected {
+			t.Errorf("Bar(%d, %d, %d, %v): got %q, want %q", tt.value, tt.peak, tt.width, tt.ascii, got, tt.expected)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values   []int
+		width    int
+		ascii    bool
+		expected string
+	}{
+		{[]int{0, 1, 2, 4, 8}, 10, false, " ▁▂▄█"},
+		{[]int{0, 1, 2, 4, 8}, 10, true, " .:=%"},
+		// Шесть дней в трёх колонках: 1+1, 0+0, 4+2
+		{[]int{1, 1, 0, 0, 4, 2}, 3, false, "▃ █"},
+		{nil, 10, false, ""},
+	}
+
+	for _, tt := range tests {
+		if got := Sparkline(tt.values, tt.width, tt.ascii); got != tt.expected {
+			t.Errorf("Sparkline(%v, %d, %v): got %q, want %q", tt.values, tt.width, tt.ascii, got, tt.expected)
+		}
+	}
+}
+
+func TestKillsPerDay(t *testing.T) {
+	day := func(d, hour int) time.Time {
+		return time.Date(2026, 1, d, hour, 0, 0, 0, time.Local)
+	}
+	entries := []parser.LogEntry{
+		{MonsterName: "Часы", Time: day(16, 6)},
+		{MonsterName: "часы", Time: day(16, 23)},
+		{MonsterName: "Росинка", Time: day(19, 0)},
+		{MonsterName: "Часы", Time: day(19, 1)},
+		{MonsterName: "Без времени"},
+	}
+
+	calculator := NewCalculator(entries)
+	calculator.SetNormalizer(func(name string) string { return strings.ToUpper(name[:2]) + name[2:] })
+	days := calculator.KillsPerDay()
+	if got := days["Часы"]; len(got) != 4 || got[0] != 2 || got[3] != 1 {
+		t.Errorf("KillsPerDay Часы: got %v", got)
+	}
+	if got := days["Росинка"]; len(got) != 4 || got[3] != 1 {
+		t.Errorf("KillsPerDay Росинка: got %v", got)
+	}
+	if _, ok := days["Без времени"]; ok {
+		t.Errorf("Entries without time should be skipped")
+	}
+
+	calculator.SetFilter("рос")
+	if days := calculator.KillsPerDay(); len(days) != 1 {
+		t.Errorf("KillsPerDay with filter: got %v", days)
+	}
+}
+
+func TestFormatTableCharts(t *testing.T) {
+	monsters := []MonsterStats{
+		{Name: "Часы", KillCount: 4, TotalExp: 400},
+		{Name: "Росинка", KillCount: 1, TotalExp: 3},
+	}
+	opts := TableOptions{
+		Chart:      ChartCount,
+		Sparklines: map[string][]int{"Часы": {3, 0, 1}, "Росинка": {0, 0, 1}},
+		ASCII:      true,
+	}
+
+	lines := strings.Split(FormatTableWithOptions(monsters, opts), "\n")
+	if !strings.HasSuffix(lines[0], "| Убийства                       | По дням") {
+		t.Errorf("Header: got %q", lines[0])
+	}
+	if want := strings.Repeat("#", 30) + " | % -"; !strings.HasSuffix(lines[2], want) {
+		t.Errorf("Top row: got %q, want suffix %q", lines[2], want)
+	}
+	if want := "| " + strings.Repeat("#", 7) + strings.Repeat(" ", 23) + " |   %"; !strings.HasSuffix(lines[3], want) {
+		t.Errorf("Second row: got %q, want suffix %q", lines[3], want)
+	}
+
+	// Таблица с графиками помещается в ширину консоли
+	opts.Width = 100
+	opts.Sparklines = map[string][]int{"Часы": make([]int, 40)}
+	for _, line := range strings.Split(strings.TrimSpace(FormatTableWithOptions(monsters, opts)), "\n") {
+		if n := len([]rune(line)); n > opts.Width {
+			t.Errorf("Line is wider than %d: %d, %q", opts.Width, n, line)
+		}
+	}
+}
+
+func TestFormatTableChartsNarrow(t *testing.T) {
+	monsters := []MonsterStats{
+		{Name: "Злая шкатулка-мимик из подземелья", KillCount: 4, TotalExp: 400},
+		{Name: "Росинка", KillCount: 1, TotalExp: 3},
+	}
+	sparklines := map[string][]int{"Росинка": make([]int, 30)}
+
+	for _, width := range []int{80, 60, 50} {
+		for _, opts := range []TableOptions{
+			{Chart: ChartCount, Width: width, ASCII: true},
+			{Sparklines: sparklines, Width: width, ASCII: true},
+			{Chart: ChartExp, Sparklines: sparklines, Columns: []string{"name", "count"}, Width: width, ASCII: true},
+		} {
+			output := strings.TrimSpace(FormatTableWithOptions(monsters, opts))
+			for _, line := range strings.Split(output, "\n") {
+				if n := len([]rune(line)); n > width {
+					t.Errorf("Width %d, chart %q: line is %d wide: %q", width, opts.Chart, n, line)
+				}
+			}
+		}
+	}
+
+	// Полосе не хватает минимальной ширины - имена укорачиваются
+	opts := TableOptions{Chart: ChartCount, Width: 60, ASCII: true}
+	lines := strings.Split(FormatTableWithOptions(monsters, opts), "\n")
+	if want := "Злая шкатулка-мимик из под... |"; !strings.HasPrefix(lines[2], want) {
+		t.Errorf("Name: got %q, want prefix %q", lines[2], want)
+	}
+}
@@ -0,0 +1,77 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+)
+
+type monsterJSON struct {
+	Name       string         `json:"name"`
+	Count      int            `json:"count"`
+	Exp        int            `json:"exp"`
+	AvgExp     int            `json:"avg_exp"`
+	Variants   []string       `json:"variants,omitempty"`
+	Characters map[string]int `json:"characters,omitempty"`
+}
+
+func FormatJSON(stats []MonsterStats) (string, error) {
+	items := make([]monsterJSON, 0, len(stats))
+	for _, s := range stats {
+		items = append(items, monsterJSON{
+			Name:       s.Name,
+			Count:      s.KillCount,
+			Exp:        s.TotalExp,
+			AvgExp:     s.AvgExp(),
+			Variants:   s.Variants,
+			Characters: s.Characters,
+		})
+	}
+
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// FormatCSV выводит выбранные колонки (по умолчанию все) без форматирования
+// чисел, чтобы файл можно было открыть в таблицах.
+func FormatCSV(stats []MonsterStats, columns []string) (string, error) {
+	if len(columns) == 0 {
+		columns = []string{"name", "count", "exp", "avg"}
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(columns); err != nil {
+		return "", err
+	}
+
+	for _, s := range stats {
+		record := make([]string, 0, len(columns))
+		for _, column := range columns {
+			switch column {
+			case "name":
+				record = append(record, s.Name)
+			case "count":
+				record = append(record, strconv.Itoa(s.KillCount))
+			case "exp":
+				record = append(record, strconv.Itoa(s.TotalExp))
+			case "avg":
+				record = append(record, strconv.Itoa(s.AvgExp()))
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,68 @@
+package stats
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFormatJSON(t *testing.T) {
+	stats := []MonsterStats{
+		{Name: "Часы", KillCount: 4, TotalExp: 70120, Variants: []string{"Часы", "часы."}},
+		{Name: "Росинка", KillCount: 3, TotalExp: 9},
+	}
+
+	output, err := FormatJSON(stats)
+	if err != nil {
+		t.Fatalf("FormatJSON failed: %v", err)
+	}
+
+	var items []map[string]any
+	if err := json.Unmarshal([]byte(output), &items); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(items))
+	}
+	if items[0]["name"] != "Часы" || items[0]["count"] != float64(4) || items[0]["avg_exp"] != float64(17530) {
+		t.Errorf("Unexpected first item: %v", items[0])
+	}
+	if _, ok := items[1]["variants"]; ok {
+		t.Errorf("Empty variants should be omitted: %v", items[1])
+	}
+}
+
+func TestFormatJSONEmpty(t *testing.T) {
+	output, err := FormatJSON(nil)
+	if err != nil {
+		t.Fatalf("FormatJSON failed: %v", err)
+	}
+	if strings.TrimSpace(output) != "[]" {
+		t.Errorf("Empty stats should produce [], got %q", output)
+	}
+}
+
+func TestFormatCSV(t *testing.T) {
+	stats := []MonsterStats{
+		{Name: "Злая шкатулка, большая", KillCount: 8, TotalExp: 22984},
+	}
+
+	output, err := FormatCSV(stats, nil)
+	if err != nil {
+		t.Fatalf("FormatCSV failed: %v", err)
+	}
+
+	expected := "name,count,exp,avg\n\"Злая шкатулка, большая\",8,22984,2873\n"
+	if output != expected {
+		t.Errorf("FormatCSV: got %q, want %q", output, expected)
+	}
+
+	output, err = FormatCSV(stats, []string{"name", "count"})
+	if err != nil {
+		t.Fatalf("FormatCSV failed: %v", err)
+	}
+	if output != "name,count\n\"Злая шкатулка, большая\",8\n" {
+		t.Errorf("FormatCSV with columns: got %q", output)
+	}
+}
@@ -0,0 +1,107 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/parser"
+)
+
+// Heatmap - убийства и опыт по дням недели (с понедельника) и часам.
+type Heatmap struct {
+	Kills [7][24]int
+	Exp   [7][24]int
+}
+
+// Оттенки ячеек от пустой до максимальной.
+var (
+	UnicodeShades = []string{"  ", "░░", "▒▒", "▓▓", "██"}
+	ASCIIShades   = []string{"  ", "..", "::", "**", "##"}
+)
+
+var weekdayNames = []string{i18n.N("Пн"), i18n.N("Вт"), i18n.N("Ср"), i18n.N("Чт"), i18n.N("Пт"), i18n.N("Сб"), i18n.N("Вс")}
+
+// WeekdayNames возвращает короткие названия дней недели с понедельника.
+func WeekdayNames() []string {
+	names := make([]string, len(weekdayNames))
+	for i, name := range weekdayNames {
+		names[i] = i18n.T(name)
+	}
+	return names
+}
+
+// NewHeatmap раскладывает убийства по дню недели и часу. Записи без
+// времени пропускаются.
+func NewHeatmap(entries []parser.LogEntry) Heatmap {
+	var h Heatmap
+	for _, e := range entries {
+		if e.MonsterName == "" || e.Time.IsZero() {
+			continue
+		}
+		day := (int(e.Time.Weekday()) + 6) % 7
+		h.Kills[day][e.Time.Hour()]++
+		h.Exp[day][e.Time.Hour()] += e.ExpGained
+	}
+	return h
+}
+
+// FormatHeatmap выводит сетку 7×24, закрашенную по убийствам или, если
+// exp, по опыту. shades - оттенки от пустой ячейки до максимальной.
+func FormatHeatmap(h Heatmap, exp bool, shades []string) string {
+	grid := h.Kills
+	if exp {
+		grid = h.Exp
+	}
+
+	peak, peakDay, peakHour := 0, 0, 0
+	for d := range grid {
+		for hour, v := range grid[d] {
+			if v > peak {
+				peak, peakDay, peakHour = v, d, hour
+			}
+		}
+	}
+	if peak == 0 {
+		return i18n.T("Нет данных для отображения") + "\n"
+	}
+
+	var b strings.Builder
+	b.WriteString("    ")
+	for hour := range 24 {
+		fmt.Fprintf(&b, "%02d ", hour)
+	}
+	b.WriteString(" " + i18n.T("Всего") + "\n")
+
+	names := WeekdayNames()
+	for d := range grid {
+		fmt.Fprintf(&b, "%-4s", names[d])
+		total := 0
+		for _, v := range grid[d] {
+			b.WriteString(shade(v, peak, shades) + " ")
+			total += v
+		}
+		fmt.Fprintf(&b, " %s\n", FormatNumberForDisplay(total))
+	}
+
+	legend := strings.Join(shades[1:], " ")
+	if exp {
+		fmt.Fprintf(&b, "\n%s\n", i18n.T("%s - от меньшего к большему; максимум %s опыта в %s %02d:00", legend, FormatNumberForDisplay(peak), names[peakDay], peakHour))
+	} else {
+		fmt.Fprintf(&b, "\n%s\n", i18n.T("%s - от меньшего к большему; максимум %s убийств в %s %02d:00", legend, FormatNumberForDisplay(peak), names[peakDay], peakHour))
+	}
+	return b.String()
+}
+
+func shade(v, peak int, shades []string) string {
+	return shades[ShadeLevel(v, peak, len(shades)-1)]
+}
+
+// ShadeLevel возвращает оттенок от 0 до levels: ноль - пустая ячейка,
+// остальные значения делятся на равные доли от максимума peak.
+func ShadeLevel(v, peak, levels int) int {
+	if v <= 0 || peak <= 0 {
+		return 0
+	}
+	return min(max((v*levels+peak-1)/peak, 1), levels)
+}
@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"RQ_MobCounter/parser"
+)
+
+func TestHeatmap(t *testing.T) {
+	// 16 января 2026 - пятница, 18 января - воскресенье
+	entries := []parser.LogEntry{
+		{MonsterName: "Часы", ExpGained: 100, Time: at(10, 0)},
+		{MonsterName: "Часы", ExpGained: 100, Time: at(10, 59)},
+		{MonsterName: "Росинка", ExpGained: 3, Time: at(21, 5)},
+		{MonsterName: "Росинка", ExpGained: 3, Time: time.Date(2026, 1, 18, 0, 30, 0, 0, time.Local)},
+		{MonsterName: "Без времени", ExpGained: 999},
+	}
+
+	h := NewHeatmap(entries)
+	if h.Kills[4][10] != 2 || h.Exp[4][10] != 200 || h.Kills[4][21] != 1 || h.Kills[6][0] != 1 {
+		t.Errorf("NewHeatmap: got kills %v", h.Kills)
+	}
+
+	lines := strings.Split(FormatHeatmap(h, false, UnicodeShades), "\n")
+	if !strings.HasPrefix(lines[0], "    00 01 02") || !strings.HasSuffix(lines[0], " 23  Всего") {
+		t.Errorf("Header: got %q", lines[0])
+	}
+	friday := "Пт  " + strings.Repeat("   ", 10) + "██ " + strings.Repeat("   ", 10) + "▒▒ " + strings.Repeat("   ", 2) + " 3"
+	if lines[5] != friday {
+		t.Errorf("Friday row:\ngot  %q\nwant %q", lines[5], friday)
+	}
+	if !strings.HasPrefix(lines[7], "Вс  ▒▒   ") {
+		t.Errorf("Sunday row: got %q", lines[7])
+	}
+	if lines[9] != "░░ ▒▒ ▓▓ ██ - от меньшего к большему; максимум 2 убийств в Пт 10:00" {
+		t.Errorf("Legend: got %q", lines[9])
+	}
+
+	// По опыту ячейки с Росинками почти пустые
+	exp := FormatHeatmap(h, true, ASCIIShades)
+	if !strings.Contains(exp, "Пт  "+strings.Repeat("   ", 10)+"## ") || !strings.Contains(exp, "максимум 200 опыта в Пт 10:00") {
+		t.Errorf("Exp heatmap:\n%s", exp)
+	}
+
+	if got := FormatHeatmap(Heatmap{}, false, UnicodeShades); got != "Нет данных для отображения\n" {
+		t.Errorf("Empty heatmap: got %q", got)
+	}
+}
+
+func TestShade(t *testing.T) {
+	tests := []struct {
+		value, peak int
+		expected    string
+	}{
+		{0, 10, "  "},
+		{1, 10, "░░"},
+		{3, 10, "▒▒"},
+		{5, 10, "▒▒"},
+		{6, 10, "▓▓"},
+		{10, 10, "██"},
+	}
+
+	for _, tt := range tests {
+		if got := shade(tt.value, tt.peak, UnicodeShades); got != tt.expected {
+			t.Errorf("shade(%d, %d): got %q, want %q", tt.value, tt.peak, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/parser"
+)
+
+const monthLayout = "2006.01"
+
+// MonthSummary - сводка по файлу лога за месяц. Size и Rows заполняются
+// из сведений о файле, остальное считает SummarizeMonth.
+type MonthSummary struct {
+	Month    string
+	Source   string
+	Size     int64
+	Rows     int
+	Kills    int
+	TotalExp int
+	First    time.Time
+	Last     time.Time
+	// GapDays - дни без единой записи об убийстве. Для текущего месяца
+	// проверяются только дни до now.
+	GapDays []time.Time
+}
+
+func SummarizeMonth(month string, entries []parser.LogEntry, now time.Time) (MonthSummary, error) {
+	start, err := time.ParseInLocation(monthLayout, month, time.Local)
+	if err != nil {
+		return MonthSummary{}, i18n.Errorf("некорректный месяц %q: %w", month, err)
+	}
+
+	summary := MonthSummary{Month: month}
+	active := make(map[int]bool)
+
+	for _, e := range entries {
+		if e.MonsterName == "" {
+			continue
+		}
+
+		summary.Kills++
+		summary.TotalExp += e.ExpGained
+
+		if e.Time.IsZero() {
+			continue
+		}
+		if summary.First.IsZero() || e.Time.Before(summary.First) {
+			summary.First = e.Time
+		}
+		if e.Time.After(summary.Last) {
+			summary.Last = e.Time
+		}
+		active[e.Time.Day()] = true
+	}
+
+	end := start.AddDate(0, 1, 0)
+	for day := start; day.Before(end) && !day.After(now); day = day.AddDate(0, 0, 1) {
+		if !active[day.Day()] {
+			summary.GapDays = append(summary.GapDays, day)
+		}
+	}
+
+	return summary, nil
+}
+
+// DayStats - убийства и опыт за один день.
+type DayStats struct {
+	Date      time.Time
+	KillCount int
+	TotalExp  int
+}
+
+// Daily группирует записи по дням в порядке возрастания даты. Дни без
+// записей не включаются, записи без времени пропускаются.
+func Daily(entries []parser.LogEntry) []DayStats {
+	index := make(map[time.Time]int)
+	var days []DayStats
+
+	for _, e := range entries {
+		if e.MonsterName == "" || e.Time.IsZero() {
+			continue
+		}
+
+		date := time.Date(e.Time.Year(), e.Time.Month(), e.Time.Day(), 0, 0, 0, 0, e.Time.Location())
+		i, ok := index[date]
+		if !ok {
+			i = len(days)
+			index[date] = i
+			days = append(days, DayStats{Date: date})
+		}
+		days[i].KillCount++
+		days[i].TotalExp += e.ExpGained
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Date.Before(days[j].Date)
+	})
+	return days
+}
+
+// FormatDays сворачивает дни в диапазоны: «1-3, 7, 10-12».
+func FormatDays(days []time.Time) string {
+	if len(days) == 0 {
+		return "-"
+	}
+
+	var parts []string
+	from := days[0]
+	prev := days[0]
+
+	flush := func() {
+		if from.Equal(prev) {
+			parts = append(parts, fmt.Sprintf("%d", from.Day()))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", from.Day(), prev.Day()))
+		}
+	}
+
+	for _, day := range days[1:] {
+		if day.Equal(prev.AddDate(0, 0, 1)) {
+			prev = day
+			continue
+		}
+		flush()
+		from, prev = day, day
+	}
+	flush()
+
+	return strings.Join(parts, ", ")
+}
+
+func FormatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return i18n.T("%.1f МБ", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return i18n.T("%.1f КБ", float64(size)/(1<<10))
+	}
+	return i18n.T("%d Б", size)
+}
+
+func FormatMonthTable(months []MonthSummary) string {
+	if len(months) == 0 {
+		return i18n.T("Нет данных для отображения") + "\n"
+	}
+
+	output := fmt.Sprintf("%-7s | %9s | %7s | %8s | %14s | %-11s | %-11s | %s\n",
+		i18n.T("Месяц"), i18n.T("Размер"), i18n.T("Строк"), i18n.T("Убийств"), i18n.T("Опыт"), i18n.T("Первая"), i18n.T("Последняя"), i18n.T("Дни без записей"))
+	output += strings.Repeat("-", 110) + "\n"
+
+	for _, m := range months {
+		output += fmt.Sprintf("%-7s | %9s | %7d | %8d | %14s | %-11s | %-11s | %s\n",
+			m.Month,
+			FormatSize(m.Size),
+			m.Rows,
+			m.Kills,
+			FormatNumberForDisplay(m.TotalExp),
+			formatShortTime(m.First),
+			formatShortTime(m.Last),
+			FormatDays(m.GapDays))
+	}
+
+	return output
+}
+
+func formatShortTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("02.01 15:04")
+}
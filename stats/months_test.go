@@ -0,0 +1,89 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"RQ_MobCounter/parser"
+)
+
+func day(d, hour int) time.Time {
+	return time.Date(2026, 1, d, hour, 0, 0, 0, time.Local)
+}
+
+func TestSummarizeMonth(t *testing.T) {
+	entries := []parser.LogEntry{
+		{MonsterName: "Часы", ExpGained: 100, Time: day(2, 10)},
+		{MonsterName: "Часы", ExpGained: 100, Time: day(3, 12)},
+		{MonsterName: "Росинка", ExpGained: 50, Time: day(6, 8)},
+		{MonsterName: "Без времени", ExpGained: 5},
+	}
+
+	summary, err := SummarizeMonth("2026.01", entries, day(8, 0))
+	if err != nil {
+		t.Fatalf("SummarizeMonth failed: %v", err)
+	}
+
+	if summary.Kills != 4 || summary.TotalExp != 255 {
+		t.Errorf("Totals: got %d kills, %d exp", summary.Kills, summary.TotalExp)
+	}
+	if !summary.First.Equal(day(2, 10)) || !summary.Last.Equal(day(6, 8)) {
+		t.Errorf("Range: got %v - %v", summary.First, summary.Last)
+	}
+
+	// Дни после now не считаются пропусками
+	if got := FormatDays(summary.GapDays); got != "1, 4-5, 7-8" {
+		t.Errorf("Gap days: got %q, want %q", got, "1, 4-5, 7-8")
+	}
+}
+
+func TestSummarizeMonthPast(t *testing.T) {
+	summary, err := SummarizeMonth("2025.02", nil, day(8, 0))
+	if err != nil {
+		t.Fatalf("SummarizeMonth failed: %v", err)
+	}
+	if len(summary.GapDays) != 28 {
+		t.Errorf("Empty February: got %d gap days, want 28", len(summary.GapDays))
+	}
+
+	if _, err := SummarizeMonth("январь", nil, day(8, 0)); err == nil {
+		t.Error("Invalid month should fail")
+	}
+}
+
+func TestDaily(t *testing.T) {
+	entries := []parser.LogEntry{
+		{MonsterName: "Росинка", ExpGained: 50, Time: day(6, 8)},
+		{MonsterName: "Часы", ExpGained: 100, Time: day(2, 10)},
+		{MonsterName: "Часы", ExpGained: 100, Time: day(2, 23)},
+		{MonsterName: "Без времени", ExpGained: 5},
+	}
+
+	days := Daily(entries)
+	if len(days) != 2 {
+		t.Fatalf("Daily: got %d days, want 2", len(days))
+	}
+	if !days[0].Date.Equal(day(2, 0)) || days[0].KillCount != 2 || days[0].TotalExp != 200 {
+		t.Errorf("First day: got %+v", days[0])
+	}
+	if !days[1].Date.Equal(day(6, 0)) || days[1].KillCount != 1 {
+		t.Errorf("Second day: got %+v", days[1])
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		size     int64
+		expected string
+	}{
+		{512, "512 Б"},
+		{2048, "2.0 КБ"},
+		{5 << 20, "5.0 МБ"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatSize(tt.size); got != tt.expected {
+			t.Errorf("FormatSize(%d): got %q, want %q", tt.size, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/parser"
+)
+
+// DefaultSessionGap - перерыв между убийствами, после которого начинается
+// новая игровая сессия.
+const DefaultSessionGap = 15 * time.Minute
+
+type Session struct {
+	Start      time.Time
+	End        time.Time
+	KillCount  int
+	TotalExp   int
+	LastKill   string
+	TopMonster string
+}
+
+func (s Session) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// ExpPerHour считает опыт в час. Для сессий короче минуты возвращает 0,
+// чтобы одно убийство не давало огромную скорость.
+func (s Session) ExpPerHour() int {
+	if s.Duration() < time.Minute {
+		return 0
+	}
+	return int(float64(s.TotalExp) / s.Duration().Hours())
+}
+
+func (s Session) KillsPerHour() int {
+	if s.Duration() < time.Minute {
+		return 0
+	}
+	return int(float64(s.KillCount) / s.Duration().Hours())
+}
+
+// Sessions разбивает записи на сессии по перерывам длиннее gap. Записи без
+// времени пропускаются.
+func Sessions(entries []parser.LogEntry, gap time.Duration) []Session {
+	var timed []parser.LogEntry
+	for _, e := range entries {
+		if !e.Time.IsZero() && e.MonsterName != "" {
+			timed = append(timed, e)
+		}
+	}
+
+	sort.SliceStable(timed, func(i, j int) bool {
+		return timed[i].Time.Before(timed[j].Time)
+	})
+
+	var sessions []Session
+	var counts map[string]int
+
+	for _, e := range timed {
+		if len(sessions) == 0 || e.Time.Sub(sessions[len(sessions)-1].End) > gap {
+			if len(sessions) > 0 {
+				sessions[len(sessions)-1].TopMonster = topMonster(counts)
+			}
+			sessions = append(sessions, Session{Start: e.Time})
+			counts = make(map[string]int)
+		}
+
+		s := &sessions[len(sessions)-1]
+		s.End = e.Time
+		s.KillCount++
+		s.TotalExp += e.ExpGained
+		s.LastKill = e.MonsterName
+		counts[e.MonsterName]++
+	}
+
+	if len(sessions) > 0 {
+		sessions[len(sessions)-1].TopMonster = topMonster(counts)
+	}
+
+	return sessions
+}
+
+func topMonster(counts map[string]int) string {
+	best := ""
+	for name, count := range counts {
+		if best == "" || count > counts[best] || (count == counts[best] && name < best) {
+			best = name
+		}
+	}
+	return best
+}
+
+func FormatSessionTable(sessions []Session) string {
+	if len(sessions) == 0 {
+		return i18n.T("Нет данных для отображения") + "\n"
+	}
+
+	output := fmt.Sprintf("%-16s | %8s | %10s | %14s | %12s | %s\n",
+		i18n.T("Начало"), i18n.T("Длит."), i18n.T("Убийств"), i18n.T("Опыт"), i18n.T("Опыт/час"), i18n.T("Чаще всего"))
+	output += strings.Repeat("-", 100) + "\n"
+
+	for _, s := range sessions {
+		output += fmt.Sprintf("%-16s | %8s | %10d | %14s | %12s | %s\n",
+			s.Start.Format("2006-01-02 15:04"),
+			FormatDuration(s.Duration()),
+			s.KillCount,
+			FormatNumberForDisplay(s.TotalExp),
+			FormatNumberForDisplay(s.ExpPerHour()),
+			s.TopMonster)
+	}
+
+	return output
+}
+
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
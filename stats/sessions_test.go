@@ -0,0 +1,75 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"RQ_MobCounter/parser"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, 1, 16, hour, minute, 0, 0, time.Local)
+}
+
+func TestSessions(t *testing.T) {
+	entries := []parser.LogEntry{
+		{MonsterName: "Часы", ExpGained: 100, Time: at(10, 0)},
+		{MonsterName: "Часы", ExpGained: 100, Time: at(10, 10)},
+		{MonsterName: "Росинка", ExpGained: 50, Time: at(10, 30)},
+		{MonsterName: "Росинка", ExpGained: 50, Time: at(12, 0)},
+		{MonsterName: "Без времени", ExpGained: 999},
+	}
+
+	sessions := Sessions(entries, DefaultSessionGap)
+
+	if len(sessions) != 3 {
+		t.Fatalf("Expected 3 sessions, got %d", len(sessions))
+	}
+
+	first := sessions[0]
+	if first.KillCount != 2 || first.TotalExp != 200 || first.Duration() != 10*time.Minute {
+		t.Errorf("First session mismatch: %+v", first)
+	}
+	if first.ExpPerHour() != 1200 {
+		t.Errorf("First session exp/hour: got %d, want 1200", first.ExpPerHour())
+	}
+	if first.TopMonster != "Часы" || first.LastKill != "Часы" {
+		t.Errorf("First session monsters: %+v", first)
+	}
+
+	if sessions[1].ExpPerHour() != 0 {
+		t.Errorf("Single kill session should have 0 exp/hour, got %d", sessions[1].ExpPerHour())
+	}
+}
+
+func TestSessionsUnsorted(t *testing.T) {
+	entries := []parser.LogEntry{
+		{MonsterName: "B", ExpGained: 1, Time: at(10, 5)},
+		{MonsterName: "A", ExpGained: 1, Time: at(10, 0)},
+	}
+
+	sessions := Sessions(entries, DefaultSessionGap)
+
+	if len(sessions) != 1 || !sessions[0].Start.Equal(at(10, 0)) || sessions[0].LastKill != "B" {
+		t.Errorf("Sessions should sort entries by time, got %+v", sessions)
+	}
+}
+
+func TestFormatSessionTable(t *testing.T) {
+	sessions := []Session{
+		{Start: at(10, 0), End: at(11, 30), KillCount: 90, TotalExp: 150000, TopMonster: "Часы"},
+	}
+
+	output := FormatSessionTable(sessions)
+
+	for _, expected := range []string{"2026-01-16 10:00", "1:30", "150 000", "100 000", "Часы"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	if !strings.Contains(FormatSessionTable(nil), "Нет данных") {
+		t.Errorf("Empty sessions should print no data message")
+	}
+}
@@ -2,9 +2,11 @@ package stats
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
+	"RQ_MobCounter/i18n"
 	"RQ_MobCounter/parser"
 )
 
@@ -12,111 +14,352 @@ type MonsterStats struct {
 	Name      string
 	KillCount int
 	TotalExp  int
+	Variants  []string
+	// Characters - количество убийств по персонажам, заполняется только
+	// для записей с указанным персонажем (отчёт по нескольким профилям).
+	Characters map[string]int
+}
+
+type CharacterStats struct {
+	Name      string
+	KillCount int
+	TotalExp  int
+}
+
+type TableOptions struct {
+	ShowExp      bool
+	ShowVariants bool
+	Columns      []string
+	// Theme - цвета таблицы, nil - без цвета.
+	Theme *Theme
+	// Chart - колонка с полосой по количеству или опыту (ChartValues),
+	// пусто - без полосы.
+	Chart string
+	// Sparklines - убийства по дням из KillsPerDay для колонки со
+	// спарклайном, nil - без неё.
+	Sparklines map[string][]int
+	// Width - ширина консоли, под которую ужимаются графики, 0 - неизвестна.
+	Width int
+	// ASCII - рисовать графики символами ASCII.
+	ASCII bool
 }
 
 type Calculator struct {
-	entries []parser.LogEntry
+	entries   []parser.LogEntry
+	normalize func(string) string
+	filter    string
+	minCount  int
 }
 
+var columnHeaders = map[string]string{
+	"name":  i18n.N("Монстр"),
+	"count": i18n.N("Количество"),
+	"exp":   i18n.N("Суммарный опыт"),
+	"avg":   i18n.N("Средний опыт"),
+}
+
+const (
+	nameWidth   = 40
+	numberWidth = 15
+)
+
 func NewCalculator(entries []parser.LogEntry) *Calculator {
 	return &Calculator{
 		entries: entries,
 	}
 }
 
+func (c *Calculator) SetNormalizer(normalize func(string) string) {
+	c.normalize = normalize
+}
+
+// SetFilter оставляет только монстров, в имени которых есть подстрока
+// (без учёта регистра).
+func (c *Calculator) SetFilter(filter string) {
+	c.filter = strings.ToLower(strings.TrimSpace(filter))
+}
+
+func (c *Calculator) SetMinCount(minCount int) {
+	c.minCount = minCount
+}
+
+func (m MonsterStats) AvgExp() int {
+	if m.KillCount == 0 {
+		return 0
+	}
+	return m.TotalExp / m.KillCount
+}
+
 func (c *Calculator) Calculate(sortBy string, limit int) []MonsterStats {
 	statsMap := make(map[string]*MonsterStats)
+	variants := make(map[string]map[string]bool)
 
 	for _, entry := range c.entries {
 		if entry.MonsterName == "" {
 			continue
 		}
 
-		if _, exists := statsMap[entry.MonsterName]; !exists {
-			statsMap[entry.MonsterName] = &MonsterStats{
-				Name: entry.MonsterName,
+		name := entry.MonsterName
+		if c.normalize != nil {
+			name = c.normalize(name)
+		}
+
+		if c.filter != "" && !strings.Contains(strings.ToLower(name), c.filter) {
+			continue
+		}
+
+		if _, exists := statsMap[name]; !exists {
+			statsMap[name] = &MonsterStats{
+				Name: name,
 			}
+			variants[name] = make(map[string]bool)
 		}
 
-		stats := statsMap[entry.MonsterName]
+		stats := statsMap[name]
 		stats.KillCount++
 		stats.TotalExp += entry.ExpGained
+		variants[name][entry.MonsterName] = true
+
+		if entry.Character != "" {
+			if stats.Characters == nil {
+				stats.Characters = make(map[string]int)
+			}
+			stats.Characters[entry.Character]++
+		}
 	}
 
 	var result []MonsterStats
-	for _, stat := range statsMap {
+	for name, stat := range statsMap {
+		if stat.KillCount < c.minCount {
+			continue
+		}
+		if c.normalize != nil {
+			for raw := range variants[name] {
+				stat.Variants = append(stat.Variants, raw)
+			}
+			sort.Strings(stat.Variants)
+		}
 		result = append(result, *stat)
 	}
 
-	sort.Slice(result, func(i, j int) bool {
-		if sortBy == "exp" {
-			if result[i].TotalExp != result[j].TotalExp {
-				return result[i].TotalExp > result[j].TotalExp
+	SortMonsters(result, sortBy)
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
+}
+
+// SortMonsters сортирует статистику как Calculate: count, exp, avg или name,
+// при равенстве - по имени.
+func SortMonsters(monsters []MonsterStats, sortBy string) {
+	sort.Slice(monsters, func(i, j int) bool {
+		switch sortBy {
+		case "exp":
+			if monsters[i].TotalExp != monsters[j].TotalExp {
+				return monsters[i].TotalExp > monsters[j].TotalExp
 			}
-		} else {
-			if result[i].KillCount != result[j].KillCount {
-				return result[i].KillCount > result[j].KillCount
+		case "avg":
+			if monsters[i].AvgExp() != monsters[j].AvgExp() {
+				return monsters[i].AvgExp() > monsters[j].AvgExp()
+			}
+		case "name":
+		default:
+			if monsters[i].KillCount != monsters[j].KillCount {
+				return monsters[i].KillCount > monsters[j].KillCount
 			}
 		}
-		return result[i].Name < result[j].Name
+		return monsters[i].Name < monsters[j].Name
 	})
+}
 
-	if limit > 0 && len(result) > limit {
-		result = result[:limit]
+// ByCharacter подсчитывает убийства и опыт по персонажам, отсортированные
+// по количеству убийств.
+func ByCharacter(entries []parser.LogEntry) []CharacterStats {
+	statsMap := make(map[string]*CharacterStats)
+
+	for _, entry := range entries {
+		if entry.MonsterName == "" || entry.Character == "" {
+			continue
+		}
+
+		if _, exists := statsMap[entry.Character]; !exists {
+			statsMap[entry.Character] = &CharacterStats{Name: entry.Character}
+		}
+		statsMap[entry.Character].KillCount++
+		statsMap[entry.Character].TotalExp += entry.ExpGained
+	}
+
+	var result []CharacterStats
+	for _, stat := range statsMap {
+		result = append(result, *stat)
 	}
 
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].KillCount != result[j].KillCount {
+			return result[i].KillCount > result[j].KillCount
+		}
+		return result[i].Name < result[j].Name
+	})
+
 	return result
 }
 
 func FormatTable(stats []MonsterStats, showExp bool) string {
+	return FormatTableWithOptions(stats, TableOptions{ShowExp: showExp})
+}
+
+func FormatTableWithOptions(stats []MonsterStats, opts TableOptions) string {
 	if len(stats) == 0 {
-		return "Нет данных для отображения\n"
+		return i18n.T("Нет данных для отображения") + "\n"
+	}
+
+	columns := resolveColumns(opts)
+
+	names := 0
+	if slices.Contains(columns, "name") {
+		names = nameWidth
+	}
+	width := 3 * (len(columns) - 1)
+	for _, column := range columns {
+		if column == "name" {
+			width += names
+		} else {
+			width += numberWidth
+		}
+	}
+
+	days := 0
+	for _, counts := range opts.Sparklines {
+		days = len(counts)
+		break
 	}
+	fitted, barWidth, sparkWidth := fitChart(opts, width, names, days)
+	width -= names - fitted
+	names = fitted
 
-	output := ""
+	var header []string
+	for _, column := range columns {
+		if column == "name" {
+			header = append(header, fmt.Sprintf("%-*s", names, truncateString(i18n.T(columnHeaders[column]), names)))
+		} else {
+			header = append(header, fmt.Sprintf("%*s", numberWidth, i18n.T(columnHeaders[column])))
+		}
+	}
+	peak := 0
+	for _, s := range stats {
+		peak = max(peak, chartValue(s, opts.Chart))
+	}
+	if barWidth > 0 {
+		header = append(header, padChart(i18n.T(chartHeaders[opts.Chart]), barWidth, sparkWidth > 0))
+		width += barWidth + 3
+	}
+	if sparkWidth > 0 {
+		header = append(header, i18n.T("По дням"))
+		width += sparkWidth + 3
+	}
 
-	if showExp {
-		output += fmt.Sprintf("%-40s | %15s | %15s\n",
-			"Монстр", "Количество", "Суммарный опыт")
-		output += strings.Repeat("-", 75) + "\n"
+	output := opts.Theme.header(strings.Join(header, " | ")) + "\n"
+	output += strings.Repeat("-", width) + "\n"
 
-		for _, s := range stats {
-			output += fmt.Sprintf("%-40s | %15d | %15s\n",
-				truncateString(s.Name, 40),
-				s.KillCount,
-				FormatNumberForDisplay(s.TotalExp))
+	for i, s := range stats {
+		var cells []string
+		for _, column := range columns {
+			var cell string
+			if column == "name" {
+				cell = fmt.Sprintf("%-*s", names, truncateString(s.Name, names))
+			} else {
+				cell = fmt.Sprintf("%*s", numberWidth, formatCell(s, column))
+			}
+			cells = append(cells, opts.Theme.cell(i, column, cell))
 		}
-	} else {
-		output += fmt.Sprintf("%-40s | %15s\n", "Монстр", "Количество")
-		output += strings.Repeat("-", 60) + "\n"
+		if barWidth > 0 {
+			cells = append(cells, padChart(Bar(chartValue(s, opts.Chart), peak, barWidth, opts.ASCII), barWidth, sparkWidth > 0))
+		}
+		if sparkWidth > 0 {
+			cells = append(cells, Sparkline(opts.Sparklines[s.Name], sparkWidth, opts.ASCII))
+		}
+		output += strings.Join(cells, " | ") + "\n"
 
-		for _, s := range stats {
-			output += fmt.Sprintf("%-40s | %15d\n",
-				truncateString(s.Name, 40),
-				s.KillCount)
+		if opts.ShowVariants {
+			output += formatVariants(s)
 		}
 	}
 
 	return output
 }
 
+// resolveColumns возвращает список колонок таблицы. Если колонки не заданы
+// явно, показываются имя и количество, а также опыт при ShowExp.
+func resolveColumns(opts TableOptions) []string {
+	if len(opts.Columns) > 0 {
+		return opts.Columns
+	}
+	if opts.ShowExp {
+		return []string{"name", "count", "exp"}
+	}
+	return []string{"name", "count"}
+}
+
+func formatCell(s MonsterStats, column string) string {
+	switch column {
+	case "count":
+		return fmt.Sprintf("%d", s.KillCount)
+	case "exp":
+		return FormatNumberForDisplay(s.TotalExp)
+	case "avg":
+		return FormatNumberForDisplay(s.AvgExp())
+	}
+	return s.Name
+}
+
+func FormatCharacterTable(stats []CharacterStats) string {
+	if len(stats) == 0 {
+		return i18n.T("Нет данных для отображения") + "\n"
+	}
+
+	output := fmt.Sprintf("%-*s | %*s | %*s\n", nameWidth, i18n.T("Персонаж"), numberWidth, i18n.T("Количество"), numberWidth, i18n.T("Суммарный опыт"))
+	output += strings.Repeat("-", nameWidth+2*numberWidth+6) + "\n"
+
+	for _, s := range stats {
+		output += fmt.Sprintf("%-*s | %*d | %*s\n",
+			nameWidth, truncateString(s.Name, nameWidth),
+			numberWidth, s.KillCount,
+			numberWidth, FormatNumberForDisplay(s.TotalExp))
+	}
+
+	return output
+}
+
+func formatVariants(s MonsterStats) string {
+	if len(s.Variants) == 0 || (len(s.Variants) == 1 && s.Variants[0] == s.Name) {
+		return ""
+	}
+	return fmt.Sprintf("    ← %s\n", strings.Join(s.Variants, ", "))
+}
+
 func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
 		return s
 	}
-	return s[:maxLen-3] + "..."
+	return string(runes[:maxLen-3]) + "..."
 }
 
+// FormatNumberForDisplay группирует разряды по правилам текущего языка:
+// 1 234 567 для русского и 1,234,567 для английского.
 func FormatNumberForDisplay(n int) string {
 	s := fmt.Sprintf("%d", n)
 	if len(s) <= 3 {
 		return s
 	}
 
+	separator := i18n.ThousandsSeparator()
 	var result strings.Builder
 	for i, c := range s {
 		if i > 0 && (len(s)-i)%3 == 0 {
-			result.WriteRune(',')
+			result.WriteString(separator)
 		}
 		result.WriteRune(c)
 	}
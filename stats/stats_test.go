@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"RQ_MobCounter/i18n"
 	"RQ_MobCounter/parser"
 )
 
@@ -89,8 +90,8 @@ func TestFormatTableWithExp(t *testing.T) {
 	if !strings.Contains(output, "Злая шкатулка") {
 		t.Errorf("Output should contain 'Злая шкатулка'")
 	}
-	if !strings.Contains(output, "8,619") {
-		t.Errorf("Output should contain '8,619'")
+	if !strings.Contains(output, "8 619") {
+		t.Errorf("Output should contain '8 619'")
 	}
 	if !strings.Contains(output, "Монстр") {
 		t.Errorf("Output should contain header 'Монстр'")
@@ -181,6 +182,7 @@ func TestTruncateString(t *testing.T) {
 		{"This is a very long monster name", 10, "This is..."},
 		{"Exactly", 7, "Exactly"},
 		{"Exactly", 6, "Exa..."},
+		{"Злая шкатулка", 10, "Злая шк..."},
 	}
 
 	for _, tt := range tests {
@@ -259,6 +261,9 @@ func TestFormatNumberForDisplay(t *testing.T) {
 		{22984, "22,984"},
 	}
 
+	i18n.SetLanguage(i18n.English)
+	defer i18n.SetLanguage(i18n.Russian)
+
 	for _, tt := range tests {
 		result := FormatNumberForDisplay(tt.input)
 		if result != tt.expected {
@@ -267,6 +272,41 @@ func TestFormatNumberForDisplay(t *testing.T) {
 	}
 }
 
+func TestFormatNumberForDisplayRussian(t *testing.T) {
+	tests := []struct {
+		input    int
+		expected string
+	}{
+		{999, "999"},
+		{1000, "1 000"},
+		{102413, "102 413"},
+		{10000000, "10 000 000"},
+	}
+
+	for _, tt := range tests {
+		result := FormatNumberForDisplay(tt.input)
+		if result != tt.expected {
+			t.Errorf("FormatNumberForDisplay(%d) = %q, expected %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestFormatTableEnglish(t *testing.T) {
+	i18n.SetLanguage(i18n.English)
+	defer i18n.SetLanguage(i18n.Russian)
+
+	output := FormatTable([]MonsterStats{{Name: "Часы", KillCount: 2, TotalExp: 35060}}, true)
+
+	for _, want := range []string{"Monster", "Count", "Total exp", "35,060"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+	if got := FormatTable(nil, true); got != "No data to display\n" {
+		t.Errorf("Empty table: got %q", got)
+	}
+}
+
 func TestSortingByExp(t *testing.T) {
 	entries := []parser.LogEntry{
 		{Timestamp: "1", MonsterName: "A", ExpGained: 100},
@@ -316,3 +356,171 @@ func TestLimit(t *testing.T) {
 		}
 	}
 }
+
+func TestCalculateWithNormalizer(t *testing.T) {
+	entries := []parser.LogEntry{
+		{Timestamp: "1", MonsterName: "Часы", ExpGained: 100},
+		{Timestamp: "2", MonsterName: "часы.", ExpGained: 100},
+		{Timestamp: "3", MonsterName: "Старые часы", ExpGained: 100},
+		{Timestamp: "4", MonsterName: "Росинка", ExpGained: 3},
+	}
+
+	calculator := NewCalculator(entries)
+	calculator.SetNormalizer(func(name string) string {
+		if name == "часы." || name == "Старые часы" {
+			return "Часы"
+		}
+		return name
+	})
+	result := calculator.Calculate("count", 0)
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 monsters after normalization, got %d", len(result))
+	}
+
+	if result[0].Name != "Часы" || result[0].KillCount != 3 {
+		t.Errorf("First should be Часы with 3 kills, got %s with %d", result[0].Name, result[0].KillCount)
+	}
+
+	expected := []string{"Старые часы", "Часы", "часы."}
+	if strings.Join(result[0].Variants, "|") != strings.Join(expected, "|") {
+		t.Errorf("Variants: got %v, want %v", result[0].Variants, expected)
+	}
+}
+
+func TestFormatTableWithVariants(t *testing.T) {
+	stats := []MonsterStats{
+		{Name: "Часы", KillCount: 3, TotalExp: 300, Variants: []string{"Часы", "Старые часы"}},
+		{Name: "Росинка", KillCount: 1, TotalExp: 3, Variants: []string{"Росинка"}},
+	}
+
+	output := FormatTableWithOptions(stats, TableOptions{ShowVariants: true})
+
+	if !strings.Contains(output, "← Часы, Старые часы") {
+		t.Errorf("Output should list folded variants, got:\n%s", output)
+	}
+	if strings.Contains(output, "← Росинка") {
+		t.Errorf("Output should not list variants when nothing was folded")
+	}
+
+	output = FormatTableWithOptions(stats, TableOptions{})
+	if strings.Contains(output, "←") {
+		t.Errorf("Output should not list variants when option is off")
+	}
+}
+
+func TestSortingByAvgAndName(t *testing.T) {
+	entries := []parser.LogEntry{
+		{Timestamp: "1", MonsterName: "B", ExpGained: 100},
+		{Timestamp: "2", MonsterName: "B", ExpGained: 100},
+		{Timestamp: "3", MonsterName: "C", ExpGained: 150},
+		{Timestamp: "4", MonsterName: "A", ExpGained: 50},
+	}
+
+	calculator := NewCalculator(entries)
+
+	result := calculator.Calculate("avg", 0)
+	if result[0].Name != "C" || result[0].AvgExp() != 150 {
+		t.Errorf("First by avg should be C with 150, got %s with %d", result[0].Name, result[0].AvgExp())
+	}
+
+	result = calculator.Calculate("name", 0)
+	names := []string{result[0].Name, result[1].Name, result[2].Name}
+	if strings.Join(names, "") != "ABC" {
+		t.Errorf("Sorting by name: got %v", names)
+	}
+}
+
+func TestFilterAndMinCount(t *testing.T) {
+	entries := []parser.LogEntry{
+		{Timestamp: "1", MonsterName: "Злая шкатулка", ExpGained: 100},
+		{Timestamp: "2", MonsterName: "Злая шкатулка", ExpGained: 100},
+		{Timestamp: "3", MonsterName: "Добрая шкатулка", ExpGained: 100},
+		{Timestamp: "4", MonsterName: "Часы", ExpGained: 100},
+	}
+
+	calculator := NewCalculator(entries)
+	calculator.SetFilter("ШКАТУЛКА")
+	result := calculator.Calculate("count", 0)
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 monsters matching filter, got %d", len(result))
+	}
+
+	calculator.SetMinCount(2)
+	result = calculator.Calculate("count", 0)
+
+	if len(result) != 1 || result[0].Name != "Злая шкатулка" {
+		t.Errorf("Expected only Злая шкатулка with min count 2, got %v", result)
+	}
+}
+
+func TestFormatTableColumns(t *testing.T) {
+	stats := []MonsterStats{
+		{Name: "Часы", KillCount: 4, TotalExp: 70120},
+	}
+
+	output := FormatTableWithOptions(stats, TableOptions{Columns: []string{"name", "avg"}})
+
+	if !strings.Contains(output, "Средний опыт") || !strings.Contains(output, "17 530") {
+		t.Errorf("Output should contain avg column, got:\n%s", output)
+	}
+	if strings.Contains(output, "Количество") {
+		t.Errorf("Output should not contain count column, got:\n%s", output)
+	}
+}
+
+func TestFormatTableTheme(t *testing.T) {
+	stats := []MonsterStats{
+		{Name: "Часы", KillCount: 4, TotalExp: 70120},
+		{Name: "Росинка", KillCount: 2, TotalExp: 6},
+	}
+	theme := Theme{Header: "<h>", Top: "<top>", TopRows: 1, Exp: "<exp>"}
+
+	output := FormatTableWithOptions(stats, TableOptions{ShowExp: true, Theme: &theme})
+	lines := strings.Split(output, "\n")
+
+	if !strings.HasPrefix(lines[0], "<h>Монстр") {
+		t.Errorf("Header should be painted, got %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[2], "<top>Часы") || !strings.Contains(lines[2], "<exp>") {
+		t.Errorf("Top row should highlight name and exp, got %q", lines[2])
+	}
+	if strings.Contains(lines[3], "<top>") || !strings.Contains(lines[3], "<exp>") {
+		t.Errorf("Second row should highlight only exp, got %q", lines[3])
+	}
+
+	// Без темы вывод не меняется
+	plain := FormatTableWithOptions(stats, TableOptions{ShowExp: true})
+	if strings.Contains(plain, "\033") || strings.Contains(plain, "<") {
+		t.Errorf("Output without theme should have no colour, got:\n%s", plain)
+	}
+}
+
+func TestByCharacter(t *testing.T) {
+	entries := []parser.LogEntry{
+		{Timestamp: "1", MonsterName: "A", ExpGained: 100, Character: "Лин"},
+		{Timestamp: "2", MonsterName: "B", ExpGained: 200, Character: "Тор"},
+		{Timestamp: "3", MonsterName: "A", ExpGained: 100, Character: "Тор"},
+		{Timestamp: "4", MonsterName: "A", ExpGained: 100},
+	}
+
+	result := ByCharacter(entries)
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 characters, got %d", len(result))
+	}
+	if result[0].Name != "Тор" || result[0].KillCount != 2 || result[0].TotalExp != 300 {
+		t.Errorf("First should be Тор with 2 kills and 300 exp, got %+v", result[0])
+	}
+
+	monsters := NewCalculator(entries).Calculate("count", 0)
+	if monsters[0].Name != "A" || monsters[0].Characters["Лин"] != 1 || monsters[0].Characters["Тор"] != 1 {
+		t.Errorf("Monster A should have per-character kills, got %+v", monsters[0].Characters)
+	}
+
+	output := FormatCharacterTable(result)
+	if !strings.Contains(output, "Персонаж") || !strings.Contains(output, "Тор") {
+		t.Errorf("Character table should contain header and names, got:\n%s", output)
+	}
+}
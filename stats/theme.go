@@ -0,0 +1,57 @@
+package stats
+
+import (
+	"sort"
+
+	"RQ_MobCounter/term"
+)
+
+// Theme задаёт цвета таблицы монстров. Пустая строка означает «без цвета».
+type Theme struct {
+	Header string
+	// Top выделяет имена первых TopRows монстров.
+	Top     string
+	TopRows int
+	// Exp выделяет колонки опыта (exp и avg).
+	Exp string
+}
+
+const DefaultTheme = "default"
+
+var Themes = map[string]Theme{
+	"default": {Header: term.Bold, Top: term.Green, TopRows: 3, Exp: term.Cyan},
+	"top":     {Header: term.Bold, Top: term.Yellow, TopRows: 10},
+	"exp":     {Header: term.Bold, Exp: term.Yellow},
+	"none":    {},
+}
+
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (t *Theme) header(text string) string {
+	if t == nil {
+		return text
+	}
+	return term.Paint(true, t.Header, text)
+}
+
+func (t *Theme) cell(row int, column, text string) string {
+	if t == nil {
+		return text
+	}
+	switch column {
+	case "name":
+		if row < t.TopRows {
+			return term.Paint(true, t.Top, text)
+		}
+	case "exp", "avg":
+		return term.Paint(true, t.Exp, text)
+	}
+	return text
+}
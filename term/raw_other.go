@@ -0,0 +1,14 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package term
+
+import (
+	"errors"
+	"os"
+
+	"RQ_MobCounter/i18n"
+)
+
+func makeRaw(f *os.File) (func() error, error) {
+	return nil, errors.New(i18n.T("сырой режим консоли не поддерживается"))
+}
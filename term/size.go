@@ -0,0 +1,69 @@
+package term
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"RQ_MobCounter/i18n"
+)
+
+// Width возвращает ширину консоли в символах или 0, если w - не консоль.
+// Переменная окружения COLUMNS важнее размера консоли, так ширину можно
+// задать и при выводе в файл.
+func Width(w io.Writer) int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	f, ok := w.(*os.File)
+	if !ok || !IsTerminal(w) {
+		return 0
+	}
+	width, _, err := consoleSize(f)
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// Size возвращает ширину и высоту консоли или нули, если w - не консоль.
+func Size(w io.Writer) (width, height int) {
+	f, ok := w.(*os.File)
+	if !ok || !IsTerminal(w) {
+		return 0, 0
+	}
+	width, height, err := consoleSize(f)
+	if err != nil {
+		return 0, 0
+	}
+	return width, height
+}
+
+// MakeRaw переводит консоль в сырой режим: клавиши приходят сразу, без
+// эха и построчной буферизации, Ctrl+C - обычный символ. Возвращает
+// функцию, которая восстанавливает прежний режим.
+func MakeRaw(f *os.File) (restore func() error, err error) {
+	if !IsTerminal(f) {
+		return nil, errors.New(i18n.T("ввод не является консолью"))
+	}
+	return makeRaw(f)
+}
+
+// Unicode сообщает, можно ли выводить псевдографику вроде █ и ▁. В Unix это
+// определяет локаль (LC_ALL, LC_CTYPE, LANG), на Windows - кодовая страница
+// консоли или Windows Terminal.
+func Unicode() bool {
+	if unicodeConsole() {
+		return true
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			value = strings.ToLower(value)
+			return strings.Contains(value, "utf-8") || strings.Contains(value, "utf8")
+		}
+	}
+	return false
+}
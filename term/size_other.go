@@ -0,0 +1,14 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package term
+
+import (
+	"errors"
+	"os"
+
+	"RQ_MobCounter/i18n"
+)
+
+func consoleSize(f *os.File) (width, height int, err error) {
+	return 0, 0, errors.New(i18n.T("размер консоли неизвестен"))
+}
@@ -0,0 +1,69 @@
+package term
+
+import (
+	"io"
+	"os"
+)
+
+const (
+	Reset  = "\033[0m"
+	Bold   = "\033[1m"
+	Red    = "\033[31m"
+	Green  = "\033[32m"
+	Yellow = "\033[33m"
+	Blue   = "\033[34m"
+	Cyan   = "\033[36m"
+)
+
+// EnvNoColor - переменная окружения из соглашения no-color.org. Непустое
+// значение отключает цвет в режиме auto.
+const EnvNoColor = "NO_COLOR"
+
+const (
+	ModeAuto   = "auto"
+	ModeAlways = "always"
+	ModeNever  = "never"
+)
+
+// IsTerminal сообщает, выводится ли w в консоль, а не в файл или канал.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// ColorEnabled решает, выводить ли цвет в w. always и never действуют
+// безусловно, auto включает цвет только для консоли без NO_COLOR и
+// TERM=dumb, которая понимает ANSI-последовательности (на Windows для этого
+// включается обработка виртуального терминала).
+func ColorEnabled(mode string, w io.Writer) bool {
+	switch mode {
+	case ModeAlways:
+		EnableVirtualTerminal(w)
+		return true
+	case ModeNever:
+		return false
+	}
+
+	if os.Getenv(EnvNoColor) != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	if !IsTerminal(w) {
+		return false
+	}
+	return EnableVirtualTerminal(w) == nil
+}
+
+func Paint(enabled bool, color, text string) string {
+	if !enabled || color == "" {
+		return text
+	}
+	return color + text + Reset
+}
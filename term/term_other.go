@@ -0,0 +1,15 @@
+//go:build !windows
+
+package term
+
+import "io"
+
+// EnableVirtualTerminal нужна только на Windows, остальные терминалы
+// понимают ANSI-последовательности сами.
+func EnableVirtualTerminal(w io.Writer) error {
+	return nil
+}
+
+func unicodeConsole() bool {
+	return false
+}
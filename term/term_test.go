@@ -0,0 +1,108 @@
+package term
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestIsTerminal(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	if IsTerminal(file) {
+		t.Error("Regular file should not be a terminal")
+	}
+	if IsTerminal(&bytes.Buffer{}) {
+		t.Error("Buffer should not be a terminal")
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	t.Setenv(EnvNoColor, "")
+	var buf bytes.Buffer
+
+	tests := []struct {
+		mode     string
+		expected bool
+	}{
+		{ModeAlways, true},
+		{ModeNever, false},
+		// Вывод перенаправлен - цвет в режиме auto не нужен
+		{ModeAuto, false},
+	}
+
+	for _, tt := range tests {
+		if got := ColorEnabled(tt.mode, &buf); got != tt.expected {
+			t.Errorf("ColorEnabled(%q): got %v, want %v", tt.mode, got, tt.expected)
+		}
+	}
+}
+
+func TestColorEnabledNoColor(t *testing.T) {
+	t.Setenv(EnvNoColor, "1")
+
+	// NO_COLOR влияет только на auto, явный --color=always важнее
+	if ColorEnabled(ModeAuto, os.Stdout) {
+		t.Error("NO_COLOR should disable colour in auto mode")
+	}
+	if !ColorEnabled(ModeAlways, &bytes.Buffer{}) {
+		t.Error("--color=always should override NO_COLOR")
+	}
+}
+
+func TestPaint(t *testing.T) {
+	if got := Paint(true, Green, "ok"); got != "\033[32mok\033[0m" {
+		t.Errorf("Paint enabled: got %q", got)
+	}
+	if got := Paint(false, Green, "ok"); got != "ok" {
+		t.Errorf("Paint disabled: got %q", got)
+	}
+	if got := Paint(true, "", "ok"); got != "ok" {
+		t.Errorf("Paint without colour: got %q", got)
+	}
+}
+
+func TestWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	if got := Width(&bytes.Buffer{}); got != 0 {
+		t.Errorf("Width of a buffer: got %d, want 0", got)
+	}
+
+	t.Setenv("COLUMNS", "120")
+	if got := Width(&bytes.Buffer{}); got != 120 {
+		t.Errorf("Width with COLUMNS: got %d, want 120", got)
+	}
+}
+
+func TestUnicode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("на Windows решает кодовая страница консоли")
+	}
+
+	tests := []struct {
+		lcAll, lcCtype, lang string
+		expected             bool
+	}{
+		{"", "", "ru_RU.UTF-8", true},
+		{"", "", "en_US.utf8", true},
+		{"", "", "", false},
+		// LC_ALL=C важнее UTF-8 в LANG
+		{"C", "", "ru_RU.UTF-8", false},
+		{"", "ru_RU.KOI8-R", "en_US.UTF-8", false},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_CTYPE", tt.lcCtype)
+		t.Setenv("LANG", tt.lang)
+		if got := Unicode(); got != tt.expected {
+			t.Errorf("Unicode(LC_ALL=%q, LC_CTYPE=%q, LANG=%q): got %v, want %v", tt.lcAll, tt.lcCtype, tt.lang, got, tt.expected)
+		}
+	}
+}
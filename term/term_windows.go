@@ -0,0 +1,113 @@
+//go:build windows
+
+package term
+
+import (
+	"errors"
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+
+	"RQ_MobCounter/i18n"
+)
+
+const enableVirtualTerminalProcessing = 0x0004
+
+const utf8CodePage = 65001
+
+// Режимы ввода консоли для сырого режима.
+const (
+	enableProcessedInput       = 0x0001
+	enableLineInput            = 0x0002
+	enableEchoInput            = 0x0004
+	enableVirtualTerminalInput = 0x0200
+)
+
+var (
+	kernel32                       = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleMode             = kernel32.NewProc("GetConsoleMode")
+	procSetConsoleMode             = kernel32.NewProc("SetConsoleMode")
+	procGetConsoleOutputCP         = kernel32.NewProc("GetConsoleOutputCP")
+	procGetConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
+)
+
+type coord struct {
+	X, Y int16
+}
+
+type smallRect struct {
+	Left, Top, Right, Bottom int16
+}
+
+type consoleScreenBufferInfo struct {
+	Size              coord
+	CursorPosition    coord
+	Attributes        uint16
+	Window            smallRect
+	MaximumWindowSize coord
+}
+
+// EnableVirtualTerminal включает обработку ANSI-последовательностей в
+// консоли Windows 10 и новее. В старых консолях возвращает ошибку.
+func EnableVirtualTerminal(w io.Writer) error {
+	f, ok := w.(*os.File)
+	if !ok {
+		return errors.New(i18n.T("вывод не является консолью"))
+	}
+
+	handle := syscall.Handle(f.Fd())
+	var mode uint32
+	if r, _, err := procGetConsoleMode.Call(uintptr(handle), uintptr(unsafe.Pointer(&mode))); r == 0 {
+		return err
+	}
+	if mode&enableVirtualTerminalProcessing != 0 {
+		return nil
+	}
+	if r, _, err := procSetConsoleMode.Call(uintptr(handle), uintptr(mode|enableVirtualTerminalProcessing)); r == 0 {
+		return err
+	}
+	return nil
+}
+
+// consoleSize возвращает размер видимой области консоли, а не буфера
+// прокрутки.
+func consoleSize(f *os.File) (width, height int, err error) {
+	var info consoleScreenBufferInfo
+	if r, _, err := procGetConsoleScreenBufferInfo.Call(f.Fd(), uintptr(unsafe.Pointer(&info))); r == 0 {
+		return 0, 0, err
+	}
+	return int(info.Window.Right-info.Window.Left) + 1, int(info.Window.Bottom-info.Window.Top) + 1, nil
+}
+
+// unicodeConsole сообщает, что консоль выводит UTF-8: в Windows Terminal
+// всегда, в старой консоли - после chcp 65001.
+func unicodeConsole() bool {
+	if os.Getenv("WT_SESSION") != "" {
+		return true
+	}
+	cp, _, _ := procGetConsoleOutputCP.Call()
+	return cp == utf8CodePage
+}
+
+// makeRaw отключает построчный ввод и эхо. Стрелки и другие клавиши
+// приходят ANSI-последовательностями, как в остальных системах.
+func makeRaw(f *os.File) (func() error, error) {
+	handle := syscall.Handle(f.Fd())
+	var old uint32
+	if r, _, err := procGetConsoleMode.Call(uintptr(handle), uintptr(unsafe.Pointer(&old))); r == 0 {
+		return nil, err
+	}
+
+	raw := old&^(enableEchoInput|enableLineInput|enableProcessedInput) | enableVirtualTerminalInput
+	if r, _, err := procSetConsoleMode.Call(uintptr(handle), uintptr(raw)); r == 0 {
+		return nil, err
+	}
+
+	return func() error {
+		if r, _, err := procSetConsoleMode.Call(uintptr(handle), uintptr(old)); r == 0 {
+			return err
+		}
+		return nil
+	}, nil
+}
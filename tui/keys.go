@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// KeyCode - вид нажатой клавиши.
+type KeyCode int
+
+const (
+	KeyRune KeyCode = iota
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyPageUp
+	KeyPageDown
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyBackspace
+	KeyEsc
+	KeyCtrlC
+)
+
+// Key - нажатая клавиша. Для печатных символов Code равен KeyRune, а сам
+// символ - в Rune.
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+// Последовательности, которые консоль присылает для специальных клавиш.
+// Home и End встречаются в двух вариантах в зависимости от терминала.
+var sequences = []struct {
+	seq  string
+	code KeyCode
+}{
+	{"\x1b[A", KeyUp},
+	{"\x1b[B", KeyDown},
+	{"\x1b[C", KeyRight},
+	{"\x1b[D", KeyLeft},
+	{"\x1bOA", KeyUp},
+	{"\x1bOB", KeyDown},
+	{"\x1bOC", KeyRight},
+	{"\x1bOD", KeyLeft},
+	{"\x1b[5~", KeyPageUp},
+	{"\x1b[6~", KeyPageDown},
+	{"\x1b[H", KeyHome},
+	{"\x1b[F", KeyEnd},
+	{"\x1b[1~", KeyHome},
+	{"\x1b[4~", KeyEnd},
+	{"\x1bOH", KeyHome},
+	{"\x1bOF", KeyEnd},
+}
+
+// ParseKeys разбирает байты, прочитанные из консоли в сыром режиме.
+// Неизвестные последовательности пропускаются, одиночный ESC - клавиша Esc.
+func ParseKeys(b []byte) []Key {
+	var keys []Key
+	for len(b) > 0 {
+		if b[0] == 0x1b {
+			key, n := parseEscape(b)
+			if n > 0 {
+				if key.Code != KeyRune {
+					keys = append(keys, key)
+				}
+				b = b[n:]
+				continue
+			}
+		}
+
+		switch b[0] {
+		case '\r', '\n':
+			keys = append(keys, Key{Code: KeyEnter})
+		case 0x7f, 0x08:
+			keys = append(keys, Key{Code: KeyBackspace})
+		case 0x03:
+			keys = append(keys, Key{Code: KeyCtrlC})
+		default:
+			r, n := utf8.DecodeRune(b)
+			if r >= ' ' {
+				keys = append(keys, Key{Code: KeyRune, Rune: r})
+			}
+			b = b[n:]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
+
+// parseEscape разбирает последовательность, начинающуюся с ESC, и
+// возвращает её длину. Незнакомая CSI-последовательность пропускается
+// целиком и возвращается как KeyRune без символа.
+func parseEscape(b []byte) (Key, int) {
+	for _, s := range sequences {
+		if bytes.HasPrefix(b, []byte(s.seq)) {
+			return Key{Code: s.code}, len(s.seq)
+		}
+	}
+	if len(b) == 1 || (b[1] != '[' && b[1] != 'O') {
+		return Key{Code: KeyEsc}, 1
+	}
+	// CSI: параметры и промежуточные байты, затем финальный байт 0x40-0x7e
+	for i := 2; i < len(b); i++ {
+		if b[i] >= 0x40 && b[i] <= 0x7e {
+			return Key{}, i + 1
+		}
+	}
+	return Key{}, len(b)
+}
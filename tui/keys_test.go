@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []Key
+	}{
+		{"q", []Key{{Code: KeyRune, Rune: 'q'}}},
+		{"рос", []Key{{Code: KeyRune, Rune: 'р'}, {Code: KeyRune, Rune: 'о'}, {Code: KeyRune, Rune: 'с'}}},
+		{"\x1b[A\x1b[B\x1bOC\x1b[D", []Key{{Code: KeyUp}, {Code: KeyDown}, {Code: KeyRight}, {Code: KeyLeft}}},
+		{"\x1b[5~\x1b[6~\x1b[1~\x1b[F", []Key{{Code: KeyPageUp}, {Code: KeyPageDown}, {Code: KeyHome}, {Code: KeyEnd}}},
+		{"\r\x7f\x03", []Key{{Code: KeyEnter}, {Code: KeyBackspace}, {Code: KeyCtrlC}}},
+		// Одиночный ESC - клавиша, а не начало последовательности
+		{"\x1b", []Key{{Code: KeyEsc}}},
+		// Незнакомая последовательность (F5) пропускается целиком
+		{"\x1b[15~s", []Key{{Code: KeyRune, Rune: 's'}}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseKeys([]byte(tt.input)); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("ParseKeys(%q): got %+v, want %+v", tt.input, got, tt.expected)
+		}
+	}
+}
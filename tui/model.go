@@ -0,0 +1,497 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"RQ_MobCounter/i18n"
+	"RQ_MobCounter/parser"
+	"RQ_MobCounter/stats"
+)
+
+// SortOrder - порядок, в котором клавиша s перебирает сортировки.
+var SortOrder = []string{"count", "exp", "avg", "name"}
+
+// Action сообщает вызывающему коду, что нужно сделать после нажатия.
+type Action int
+
+const (
+	ActionNone Action = iota
+	// ActionLoad - выбран другой месяц, нужно загрузить его записи и
+	// передать в SetEntries.
+	ActionLoad
+	ActionQuit
+)
+
+// Размеры интерфейса в строках и символах.
+const (
+	detailHeight = 10
+	numberWidth  = 12
+	// minDetailScreen - меньше этой высоты панель подробностей скрывается
+	minDetailScreen = 20
+	// expLines - сколько значений опыта показывать в распределении
+	expLines = 4
+)
+
+// Model - состояние полноэкранного просмотра: месяцы, записи выбранного
+// месяца, сортировка, фильтр и выбранная строка. Model не работает с
+// консолью сама: клавиши передаются в HandleKey, а View возвращает строки
+// экрана.
+type Model struct {
+	months    []string
+	month     int
+	liveMonth string
+
+	entries  []parser.LogEntry
+	monsters []stats.MonsterStats
+	sortBy   string
+	filter   string
+	editing  bool
+
+	selected int
+	offset   int
+	width    int
+	height   int
+	ascii    bool
+	noColor  bool
+	status   string
+}
+
+// New создаёт модель с выбранным последним месяцем. Записи передаются
+// отдельно через SetEntries.
+func New(months []string, sortBy string) *Model {
+	m := &Model{months: months, month: len(months) - 1, sortBy: sortBy, width: 80, height: 24}
+	if m.month < 0 {
+		m.month = 0
+	}
+	return m
+}
+
+// SelectMonth выбирает месяц по имени (YYYY.MM) и сообщает, найден ли он.
+func (m *Model) SelectMonth(month string) bool {
+	for i, name := range m.months {
+		if name == month {
+			m.month = i
+			return true
+		}
+	}
+	return false
+}
+
+// Month возвращает выбранный месяц или пустую строку, если месяцев нет.
+func (m *Model) Month() string {
+	if len(m.months) == 0 {
+		return ""
+	}
+	return m.months[m.month]
+}
+
+// SetMonths заменяет список месяцев, сохраняя выбранный.
+func (m *Model) SetMonths(months []string) {
+	current := m.Month()
+	m.months = months
+	if !m.SelectMonth(current) {
+		m.month = max(len(months)-1, 0)
+	}
+}
+
+// SetLiveMonth отмечает месяц, лог которого обновляется на лету.
+func (m *Model) SetLiveMonth(month string) {
+	m.liveMonth = month
+}
+
+// SetEntries заменяет записи выбранного месяца. Выбранный монстр, фильтр
+// и сортировка сохраняются.
+func (m *Model) SetEntries(entries []parser.LogEntry) {
+	m.entries = entries
+	m.recalculate()
+}
+
+func (m *Model) Resize(width, height int) {
+	m.width = max(width, 20)
+	m.height = max(height, 5)
+	m.scroll()
+}
+
+// SetASCII включает рисование графиков символами ASCII.
+func (m *Model) SetASCII(ascii bool) {
+	m.ascii = ascii
+}
+
+// SetStatus показывает сообщение (например, об ошибке загрузки) вместо
+// подсказки до следующего нажатия.
+// SetColor включает или выключает оформление управляющими
+// последовательностями (--color, NO_COLOR). Без него выбранная строка
+// отмечается знаком «>».
+func (m *Model) SetColor(color bool) {
+	m.noColor = !color
+}
+
+func (m *Model) SetStatus(status string) {
+	m.status = status
+}
+
+// Selected возвращает выбранного монстра.
+func (m *Model) Selected() (stats.MonsterStats, bool) {
+	if m.selected >= len(m.monsters) {
+		return stats.MonsterStats{}, false
+	}
+	return m.monsters[m.selected], true
+}
+
+func (m *Model) recalculate() {
+	current, ok := m.Selected()
+
+	calculator := stats.NewCalculator(m.entries)
+	calculator.SetFilter(m.filter)
+	m.monsters = calculator.Calculate(m.sortBy, 0)
+
+	m.selected = 0
+	if ok {
+		for i, s := range m.monsters {
+			if s.Name == current.Name {
+				m.selected = i
+				break
+			}
+		}
+	}
+	m.scroll()
+}
+
+// HandleKey обрабатывает нажатие и возвращает, что нужно сделать дальше.
+func (m *Model) HandleKey(k Key) Action {
+	if k.Code == KeyCtrlC {
+		return ActionQuit
+	}
+	m.status = ""
+	if m.editing {
+		m.editFilter(k)
+		return ActionNone
+	}
+
+	switch k.Code {
+	case KeyUp:
+		m.move(-1)
+	case KeyDown:
+		m.move(1)
+	case KeyPageUp:
+		m.move(-m.tableRows())
+	case KeyPageDown:
+		m.move(m.tableRows())
+	case KeyHome:
+		m.move(-len(m.monsters))
+	case KeyEnd:
+		m.move(len(m.monsters))
+	case KeyLeft:
+		return m.switchMonth(-1)
+	case KeyRight:
+		return m.switchMonth(1)
+	case KeyEsc:
+		if m.filter != "" {
+			m.filter = ""
+			m.recalculate()
+		}
+	case KeyRune:
+		switch k.Rune {
+		case 'q':
+			return ActionQuit
+		case 'k':
+			m.move(-1)
+		case 'j':
+			m.move(1)
+		case 's':
+			m.cycleSort()
+		case '/':
+			m.editing = true
+		case '[':
+			return m.switchMonth(-1)
+		case ']':
+			return m.switchMonth(1)
+		}
+	}
+	return ActionNone
+}
+
+// editFilter меняет фильтр по мере ввода: таблица пересчитывается после
+// каждого символа. Enter оставляет фильтр, Esc сбрасывает.
+func (m *Model) editFilter(k Key) {
+	switch k.Code {
+	case KeyEnter:
+		m.editing = false
+		return
+	case KeyEsc:
+		m.editing = false
+		m.filter = ""
+	case KeyBackspace:
+		runes := []rune(m.filter)
+		if len(runes) == 0 {
+			return
+		}
+		m.filter = string(runes[:len(runes)-1])
+	case KeyRune:
+		m.filter += strings.ToLower(string(k.Rune))
+	default:
+		return
+	}
+	m.recalculate()
+}
+
+func (m *Model) cycleSort() {
+	next := 0
+	for i, s := range SortOrder {
+		if s == m.sortBy {
+			next = (i + 1) % len(SortOrder)
+		}
+	}
+	m.sortBy = SortOrder[next]
+	m.recalculate()
+}
+
+func (m *Model) switchMonth(delta int) Action {
+	month := m.month + delta
+	if month < 0 || month >= len(m.months) {
+		return ActionNone
+	}
+	m.month = month
+	return ActionLoad
+}
+
+func (m *Model) move(delta int) {
+	m.selected = min(max(m.selected+delta, 0), max(len(m.monsters)-1, 0))
+	m.scroll()
+}
+
+// scroll прокручивает таблицу так, чтобы выбранная строка была видна.
+func (m *Model) scroll() {
+	rows := m.tableRows()
+	if m.selected < m.offset {
+		m.offset = m.selected
+	}
+	if m.selected >= m.offset+rows {
+		m.offset = m.selected - rows + 1
+	}
+	m.offset = max(min(m.offset, len(m.monsters)-rows), 0)
+}
+
+// showDetail сообщает, хватает ли высоты для панели подробностей.
+func (m *Model) showDetail() bool {
+	return m.height >= minDetailScreen
+}
+
+// tableRows - число строк таблицы без заголовков, панели и подсказки.
+func (m *Model) tableRows() int {
+	rows := m.height - 3
+	if m.showDetail() {
+		rows -= detailHeight + 1
+	}
+	return max(rows, 1)
+}
+
+// View возвращает ровно height строк экрана шириной width символов.
+// Выбранная строка и заголовок выделены ANSI-последовательностями.
+func (m *Model) View() []string {
+	lines := []string{m.titleLine(), m.bold(m.fit(m.marker(false) + m.tableHeader()))}
+
+	rows := m.tableRows()
+	for i := m.offset; i < m.offset+rows; i++ {
+		switch {
+		case i < len(m.monsters):
+			line := m.fit(m.marker(i == m.selected) + m.tableRow(m.monsters[i]))
+			if i == m.selected && !m.noColor {
+				line = reverse(line)
+			}
+			lines = append(lines, line)
+		case i == 0:
+			lines = append(lines, m.fit(i18n.T("Нет данных для отображения")))
+		default:
+			lines = append(lines, m.fit(""))
+		}
+	}
+
+	if m.showDetail() {
+		separator := "─"
+		if m.ascii {
+			separator = "-"
+		}
+		lines = append(lines, m.fit(strings.Repeat(separator, m.width)))
+		for _, line := range m.detail() {
+			lines = append(lines, m.fit(line))
+		}
+	}
+
+	lines = append(lines, m.footer())
+	return lines
+}
+
+func (m *Model) titleLine() string {
+	month := m.Month()
+	if month == "" {
+		month = "-"
+	}
+	title := i18n.T("Месяц: %s (%d/%d)", month, m.month+1, len(m.months))
+	if month == m.liveMonth {
+		title += " " + i18n.T("[обновляется]")
+	}
+	title += "   " + i18n.T("Сортировка: %s", m.sortBy)
+	if m.filter != "" || m.editing {
+		title += "   " + i18n.T("Фильтр: %s", m.filter)
+	}
+	return m.bold(m.fit(title))
+}
+
+func (m *Model) footer() string {
+	if m.editing {
+		return m.fit(i18n.T("Фильтр: %s_   Enter - готово, Esc - сбросить", m.filter))
+	}
+	if m.status != "" {
+		return m.fit(m.status)
+	}
+	return m.fit(i18n.T("↑↓ выбор  ←→ месяц  s сортировка  / фильтр  Esc сбросить фильтр  q выход"))
+}
+
+func (m *Model) nameWidth() int {
+	return max(m.width-3*(numberWidth+1)-len(m.marker(false)), 10)
+}
+
+// marker - отметка выбранной строки, когда выделение цветом выключено.
+func (m *Model) marker(selected bool) string {
+	switch {
+	case !m.noColor:
+		return ""
+	case selected:
+		return "> "
+	}
+	return "  "
+}
+
+func (m *Model) tableHeader() string {
+	return fmt.Sprintf("%-*s %*s %*s %*s", m.nameWidth(), i18n.T("Монстр"),
+		numberWidth, i18n.T("Количество"), numberWidth, i18n.T("Опыт"), numberWidth, i18n.T("Средний"))
+}
+
+func (m *Model) tableRow(s stats.MonsterStats) string {
+	return fmt.Sprintf("%-*s %*s %*s %*s", m.nameWidth(), truncate(s.Name, m.nameWidth()),
+		numberWidth, stats.FormatNumberForDisplay(s.KillCount),
+		numberWidth, stats.FormatNumberForDisplay(s.TotalExp),
+		numberWidth, stats.FormatNumberForDisplay(s.AvgExp()))
+}
+
+// detail - панель выбранного монстра: итоги, убийства по дням месяца и по
+// часам, самые частые значения опыта за убийство.
+func (m *Model) detail() []string {
+	lines := make([]string, 0, detailHeight)
+	s, ok := m.Selected()
+	if ok {
+		var kills []parser.LogEntry
+		for _, e := range m.entries {
+			if e.MonsterName == s.Name {
+				kills = append(kills, e)
+			}
+		}
+
+		lines = append(lines, i18n.T("%s: убийств %s, опыт %s", s.Name,
+			stats.FormatNumberForDisplay(s.KillCount), stats.FormatNumberForDisplay(s.TotalExp)))
+		lines = append(lines, m.timeline(kills)...)
+		lines = append(lines, m.expDistribution(kills)...)
+	}
+
+	for len(lines) < detailHeight {
+		lines = append(lines, "")
+	}
+	return lines[:detailHeight]
+}
+
+// timeline - первое и последнее убийство и столбики по дням месяца и по
+// часам суток.
+func (m *Model) timeline(kills []parser.LogEntry) []string {
+	var timed []time.Time
+	for _, e := range kills {
+		if !e.Time.IsZero() {
+			timed = append(timed, e.Time)
+		}
+	}
+	if len(timed) == 0 {
+		return []string{i18n.T("Время убийств неизвестно")}
+	}
+	sort.Slice(timed, func(i, j int) bool { return timed[i].Before(timed[j]) })
+
+	first := timed[0]
+	days := make([]int, time.Date(first.Year(), first.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day())
+	hours := make([]int, 24)
+	for _, t := range timed {
+		if t.Year() == first.Year() && t.Month() == first.Month() {
+			days[t.Day()-1]++
+		}
+		hours[t.Hour()]++
+	}
+
+	const label = 10
+	width := max(m.width-label, 1)
+	return []string{
+		i18n.T("Первое: %s, последнее: %s", first.Format("2006-01-02 15:04"), timed[len(timed)-1].Format("2006-01-02 15:04")),
+		fmt.Sprintf("%-*s%s", label, i18n.T("По дням"), stats.Sparkline(days, width, m.ascii)),
+		fmt.Sprintf("%-*s%s", label, i18n.T("По часам"), stats.Sparkline(hours, width, m.ascii)),
+	}
+}
+
+// expDistribution - сколько раз монстр дал каждое значение опыта, самые
+// частые значения сверху.
+func (m *Model) expDistribution(kills []parser.LogEntry) []string {
+	counts := make(map[int]int)
+	for _, e := range kills {
+		counts[e.ExpGained]++
+	}
+	values := make([]int, 0, len(counts))
+	peak := 0
+	for v, n := range counts {
+		values = append(values, v)
+		peak = max(peak, n)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if counts[values[i]] != counts[values[j]] {
+			return counts[values[i]] > counts[values[j]]
+		}
+		return values[i] > values[j]
+	})
+
+	lines := []string{i18n.T("Опыт за убийство:")}
+	for i, v := range values {
+		if i == expLines {
+			lines = append(lines, i18n.T("  ещё значений: %d", len(values)-expLines))
+			break
+		}
+		prefix := fmt.Sprintf("  %*s × %-6d ", numberWidth, stats.FormatNumberForDisplay(v), counts[v])
+		lines = append(lines, prefix+stats.Bar(counts[v], peak, max(m.width-len([]rune(prefix)), 1), m.ascii))
+	}
+	return lines
+}
+
+// fit обрезает или дополняет строку пробелами до ширины экрана.
+func (m *Model) fit(s string) string {
+	runes := []rune(s)
+	if len(runes) > m.width {
+		return string(runes[:m.width])
+	}
+	return s + strings.Repeat(" ", m.width-len(runes))
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+func (m *Model) bold(s string) string {
+	if m.noColor {
+		return s
+	}
+	return "\033[1m" + s + "\033[0m"
+}
+
+func reverse(s string) string {
+	return "\033[7m" + s + "\033[0m"
+}
@@ -0,0 +1,228 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"RQ_MobCounter/parser"
+)
+
+func kill(name string, exp int, at string) parser.LogEntry {
+	t, _ := time.Parse("2006-01-02 15:04", at)
+	return parser.LogEntry{MonsterName: name, ExpGained: exp, Time: t}
+}
+
+var testEntries = []parser.LogEntry{
+	kill("Часы", 100, "2026-01-16 06:45"),
+	kill("Часы", 100, "2026-01-16 06:51"),
+	kill("Часы", 120, "2026-01-17 10:00"),
+	kill("Злая шкатулка", 500, "2026-01-17 11:00"),
+	kill("Росинка", 50, "2026-01-17 12:00"),
+	kill("Росинка", 50, "2026-01-18 12:00"),
+}
+
+func newTestModel(width, height int) *Model {
+	m := New([]string{"2025.12", "2026.01"}, "count")
+	m.Resize(width, height)
+	m.SetEntries(testEntries)
+	return m
+}
+
+func names(m *Model) []string {
+	var result []string
+	for _, s := range m.monsters {
+		result = append(result, s.Name)
+	}
+	return result
+}
+
+func typeKeys(m *Model, s string) Action {
+	action := ActionNone
+	for _, k := range ParseKeys([]byte(s)) {
+		action = m.HandleKey(k)
+	}
+	return action
+}
+
+func TestModelSort(t *testing.T) {
+	m := newTestModel(80, 24)
+
+	expected := []struct {
+		sortBy string
+		first  string
+	}{
+		{"exp", "Злая шкатулка"},
+		{"avg", "Злая шкатулка"},
+		{"name", "Злая шкатулка"},
+		{"count", "Часы"},
+	}
+	if got := names(m)[0]; got != "Часы" {
+		t.Errorf("Initial first monster: got %q, want %q", got, "Часы")
+	}
+	for _, tt := range expected {
+		typeKeys(m, "s")
+		if m.sortBy != tt.sortBy {
+			t.Errorf("Sort after s: got %q, want %q", m.sortBy, tt.sortBy)
+		}
+		if got := names(m)[0]; got != tt.first {
+			t.Errorf("First monster by %s: got %q, want %q", tt.sortBy, got, tt.first)
+		}
+	}
+}
+
+func TestModelSelectionKeptByName(t *testing.T) {
+	m := newTestModel(80, 24)
+
+	typeKeys(m, "j")
+	selected, _ := m.Selected()
+	if selected.Name != "Росинка" {
+		t.Fatalf("Selected after j: got %q, want %q", selected.Name, "Росинка")
+	}
+
+	// После смены сортировки и новых записей выбран тот же монстр
+	typeKeys(m, "s")
+	m.SetEntries(append(testEntries, kill("Новый", 10, "2026-01-19 00:00")))
+	if got, _ := m.Selected(); got.Name != "Росинка" {
+		t.Errorf("Selected after resort: got %q, want %q", got.Name, "Росинка")
+	}
+}
+
+func TestModelFilter(t *testing.T) {
+	m := newTestModel(80, 24)
+
+	typeKeys(m, "/РО")
+	if !m.editing || m.filter != "ро" {
+		t.Errorf("Filter while typing: got %q (editing %v), want %q", m.filter, m.editing, "ро")
+	}
+	if got := strings.Join(names(m), ","); got != "Росинка" {
+		t.Errorf("Filtered monsters: got %q, want %q", got, "Росинка")
+	}
+
+	// q во время ввода - часть фильтра, а не выход
+	if action := typeKeys(m, "q\x7f\r"); action != ActionNone {
+		t.Errorf("Action while editing: got %v, want %v", action, ActionNone)
+	}
+	if m.editing || m.filter != "ро" {
+		t.Errorf("Filter after Enter: got %q (editing %v), want %q", m.filter, m.editing, "ро")
+	}
+
+	typeKeys(m, "\x1b")
+	if m.filter != "" || len(m.monsters) != 3 {
+		t.Errorf("After Esc: got filter %q and %d monsters, want empty and 3", m.filter, len(m.monsters))
+	}
+}
+
+func TestModelScroll(t *testing.T) {
+	// Высоты 8 хватает на 5 строк таблицы, панель подробностей скрыта
+	m := newTestModel(80, 8)
+	var entries []parser.LogEntry
+	for i := range 20 {
+		entries = append(entries, kill(string(rune('A'+i)), 10, "2026-01-01 00:00"))
+	}
+	m.SetEntries(entries)
+
+	typeKeys(m, "\x1b[F")
+	if m.selected != 19 || m.offset != 15 {
+		t.Errorf("After End: got selected %d offset %d, want 19 15", m.selected, m.offset)
+	}
+	typeKeys(m, "\x1b[5~")
+	if m.selected != 14 || m.offset != 14 {
+		t.Errorf("After PgUp: got selected %d offset %d, want 14 14", m.selected, m.offset)
+	}
+	typeKeys(m, "\x1b[H\x1b[A")
+	if m.selected != 0 || m.offset != 0 {
+		t.Errorf("After Home: got selected %d offset %d, want 0 0", m.selected, m.offset)
+	}
+}
+
+func TestModelSwitchMonth(t *testing.T) {
+	m := newTestModel(80, 24)
+
+	if m.Month() != "2026.01" {
+		t.Fatalf("Initial month: got %q, want %q", m.Month(), "2026.01")
+	}
+	tests := []struct {
+		keys   string
+		action Action
+		month  string
+	}{
+		{"]", ActionNone, "2026.01"},
+		{"\x1b[D", ActionLoad, "2025.12"},
+		{"[", ActionNone, "2025.12"},
+		{"\x1b[C", ActionLoad, "2026.01"},
+	}
+	for _, tt := range tests {
+		if action := typeKeys(m, tt.keys); action != tt.action || m.Month() != tt.month {
+			t.Errorf("Keys %q: got %v %q, want %v %q", tt.keys, action, m.Month(), tt.action, tt.month)
+		}
+	}
+
+	if typeKeys(m, "q") != ActionQuit {
+		t.Errorf("q should quit")
+	}
+}
+
+func TestModelView(t *testing.T) {
+	for _, size := range [][2]int{{80, 24}, {40, 8}} {
+		m := newTestModel(size[0], size[1])
+		m.SetLiveMonth("2026.01")
+
+		view := m.View()
+		if len(view) != size[1] {
+			t.Errorf("View %dx%d: got %d lines", size[0], size[1], len(view))
+		}
+		for i, line := range view {
+			plain := strings.NewReplacer("\033[1m", "", "\033[7m", "", "\033[0m", "").Replace(line)
+			if n := utf8.RuneCountInString(plain); n != size[0] {
+				t.Errorf("View %dx%d line %d: got width %d", size[0], size[1], i, n)
+			}
+		}
+		if !strings.Contains(view[0], "[обновляется]") {
+			t.Errorf("View %dx%d title: got %q, want live marker", size[0], size[1], view[0])
+		}
+	}
+}
+
+func TestModelViewNoColor(t *testing.T) {
+	m := newTestModel(80, 24)
+	m.SetColor(false)
+
+	view := m.View()
+	for i, line := range view {
+		if strings.Contains(line, "\033") {
+			t.Errorf("line %d: got escape sequence in %q", i, line)
+		}
+		if n := utf8.RuneCountInString(line); n != 80 {
+			t.Errorf("line %d: got width %d", i, n)
+		}
+	}
+	if !strings.HasPrefix(view[2], "> ") || !strings.HasPrefix(view[3], "  ") {
+		t.Errorf("selection: got %q and %q, want \"> \" marker on the first row", view[2], view[3])
+	}
+}
+
+func TestModelDetail(t *testing.T) {
+	m := newTestModel(80, 24)
+
+	screen := strings.Join(m.View(), "\n")
+	for _, want := range []string{
+		"Часы: убийств 3, опыт 320",
+		"Первое: 2026-01-16 06:45, последнее: 2026-01-17 10:00",
+		"По дням",
+		"По часам",
+		"100 × 2",
+		"120 × 1",
+	} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Detail: missing %q in\n%s", want, screen)
+		}
+	}
+
+	// На низком экране панель не помещается
+	m.Resize(80, 10)
+	if screen := strings.Join(m.View(), "\n"); strings.Contains(screen, "По дням") {
+		t.Errorf("Detail shown on a 10-line screen")
+	}
+}